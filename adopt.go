@@ -0,0 +1,38 @@
+package zapctxd
+
+import "sync"
+
+// Adopt returns a copy of logger that uses encoder, output and level enabler of parent.
+//
+// Fields and name attached to logger are kept, so that a plugin can extend a logger it receives.
+func (l *Logger) Adopt(parent *Logger) *Logger {
+	if parent.mu != nil {
+		parent.mu.RLock()
+		defer parent.mu.RUnlock()
+	}
+
+	nl := *l
+
+	nl.levelEnabler = parent.levelEnabler
+	nl.encoder = parent.encoder
+	nl.encoderOptions = parent.encoderOptions
+	nl.format = parent.format
+	nl.out = parent.out
+	nl.closers = nil
+
+	if parent.mu == nil {
+		// Parent is created with zap loggers, so its cores are used directly.
+		nl.mu = nil
+		nl.zl = parent.zl.Named(l.name).With(l.fields...)
+		nl.zdebug = parent.zdebug.Named(l.name).With(l.fields...)
+		nl.sugared = nl.zl.Sugar()
+		nl.debug = nl.zdebug.Sugar()
+
+		return &nl
+	}
+
+	nl.mu = &sync.RWMutex{}
+	nl.make()
+
+	return &nl
+}
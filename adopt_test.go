@@ -0,0 +1,42 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_Adopt(t *testing.T) {
+	parentOut := bytes.NewBuffer(nil)
+	pluginOut := bytes.NewBuffer(nil)
+
+	parent := zapctxd.New(zapctxd.Config{
+		Level:     zap.WarnLevel,
+		StripTime: true,
+		Output:    parentOut,
+	})
+
+	plugin := zapctxd.New(zapctxd.Config{
+		Level:  zap.DebugLevel,
+		Output: pluginOut,
+	}).WithNodeID("plugin-1")
+
+	adopted := plugin.Adopt(parent)
+
+	adopted.Info(context.Background(), "skipped")
+	adopted.Warn(context.Background(), "hello")
+
+	// Level enabler is shared with parent.
+	assert.NoError(t, parent.Reload(zapctxd.Config{Level: zap.InfoLevel}))
+	adopted.Info(context.Background(), "after level change")
+
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"hello","node_id":"plugin-1"}
+{"level":"info","time":"<stripped>","msg":"after level change","node_id":"plugin-1"}
+`, parentOut.String())
+	assert.Empty(t, pluginOut.String())
+}
@@ -0,0 +1,116 @@
+package zapctxd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	alertQueueSize = 100
+	alertTimeout   = 5 * time.Second
+)
+
+// WithAlertManager returns a clone of logger that posts written entries of level and above to webhook URL.
+//
+// Entry is sent as JSON object with "level", "msg", "time" and merged fields. Requests are sent
+// asynchronously with 5s timeout and retried once, failures are logged with original logger.
+// Up to 100 alerts are queued, others are dropped and counted in LoggerMetrics.DroppedEntries.
+// Original logger is not affected, Close of the clone sends queued alerts and stops alerting.
+func (l *Logger) WithAlertManager(webhookURL string, level zapcore.Level) *Logger {
+	a := &alertWebhook{
+		url:    webhookURL,
+		client: &http.Client{Timeout: alertTimeout},
+		logger: l,
+	}
+
+	a.worker = newAsyncWorker(alertQueueSize, a.send, nil)
+	a.worker.metrics = l.metrics
+
+	nl := l.withHook(func(e entry) {
+		if e.level < level {
+			return
+		}
+
+		fields := make(map[string]any, 3+len(e.kv)/2)
+
+		for i := 0; i < len(e.kv)-1; i += 2 {
+			fields[fmt.Sprint(e.kv[i])] = e.kv[i+1]
+		}
+
+		fields["level"] = e.level.String()
+		fields["msg"] = e.msg
+		fields["time"] = time.Now()
+
+		_ = a.worker.enqueue(alert{msg: e.msg, fields: fields}) //nolint:errcheck // Alerts are not sent after Close.
+	})
+
+	nl.closers = append(l.closers[:len(l.closers):len(l.closers)], a.Close)
+
+	return nl
+}
+
+type alert struct {
+	msg    string
+	fields map[string]any
+}
+
+type alertWebhook struct {
+	url    string
+	client *http.Client
+	logger *Logger
+	worker *asyncWorker[alert]
+}
+
+func (a *alertWebhook) send(al alert) {
+	body, err := json.Marshal(al.fields)
+	if err != nil {
+		a.logger.sugared.Warnw("failed to encode alert", "error", err.Error(), "entry_msg", al.msg)
+
+		return
+	}
+
+	if err = a.post(body); err != nil {
+		err = a.post(body)
+	}
+
+	if err != nil {
+		a.logger.sugared.Warnw("failed to send alert", "error", err.Error(), "entry_msg", al.msg)
+	}
+}
+
+func (a *alertWebhook) post(body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Close sends queued alerts and stops alerting.
+func (a *alertWebhook) Close() error {
+	a.worker.close()
+
+	return nil
+}
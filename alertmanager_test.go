@@ -0,0 +1,124 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithAlertManager(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		alerts   []map[string]any
+		requests int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+
+		// First request fails to check retry.
+		if requests == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		var alert map[string]any
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		delete(alert, "time")
+
+		alerts = append(alerts, alert)
+	}))
+	defer srv.Close()
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: w,
+	})
+
+	a := c.WithAlertManager(srv.URL, zap.ErrorLevel)
+	ctx := ctxd.AddFields(context.Background(), "foo", 1)
+
+	a.Warn(ctx, "skipped")
+	a.Error(ctx, "failed", "bar", "baz")
+
+	require.NoError(t, a.Close())
+
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, []map[string]any{
+		{"level": "error", "msg": "failed", "foo": 1.0, "bar": "baz"},
+	}, alerts)
+	assert.NotContains(t, w.String(), "failed to send alert")
+}
+
+func TestLogger_WithAlertManager_failed(t *testing.T) {
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithAlertManager(srv.URL, zap.WarnLevel)
+
+	c.Warn(context.Background(), "slow")
+	require.NoError(t, c.Close())
+
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"slow"}
+{"level":"warn","time":"<stripped>","msg":"failed to send alert","error":"unexpected response status: 500 Internal Server Error","entry_msg":"slow"}
+`, w.String())
+}
+
+func TestLogger_WithAlertManager_closed(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+	}))
+	defer srv.Close()
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	}).WithAlertManager(srv.URL, zap.ErrorLevel).WithAlertManager(srv.URL, zap.ErrorLevel)
+
+	c.Error(context.Background(), "failed")
+	require.NoError(t, c.Close())
+
+	// Close of the clone waits for alerts of both webhooks.
+	assert.Equal(t, 2, requests)
+
+	c.Error(context.Background(), "failed")
+	require.NoError(t, c.Close())
+
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,241 @@
+package zapctxd
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("writer is closed")
+
+type asyncItem[T any] struct {
+	v T
+
+	// marker is set for items of Sync, they have no value.
+	marker bool
+	// syncs are answered after item is handled, they come from Sync of item or of dropped older items.
+	syncs []chan error
+}
+
+// asyncWorker passes queued values to handle in a background goroutine.
+type asyncWorker[T any] struct {
+	handle func(v T)
+	// flush is called in background goroutine on Sync after preceding values are handled, if set.
+	flush func() error
+
+	queue   chan asyncItem[T]
+	done    chan struct{}
+	dropped atomic.Int64
+
+	// dropOldest makes a ring buffer of queue, the oldest value is dropped instead of the new one when queue is full.
+	dropOldest bool
+	// block makes enqueue wait for space in queue instead of dropping values.
+	block   bool
+	metrics *metrics
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newAsyncWorker[T any](size int, handle func(v T), flush func() error) *asyncWorker[T] {
+	a := &asyncWorker[T]{
+		handle: handle,
+		flush:  flush,
+		queue:  make(chan asyncItem[T], size),
+		done:   make(chan struct{}),
+	}
+
+	go a.run()
+
+	return a
+}
+
+func (a *asyncWorker[T]) run() {
+	defer close(a.done)
+
+	for it := range a.queue {
+		if !it.marker {
+			a.handle(it.v)
+		}
+
+		if len(it.syncs) == 0 {
+			continue
+		}
+
+		var err error
+
+		if a.flush != nil {
+			err = a.flush()
+		}
+
+		for _, res := range it.syncs {
+			res <- err
+		}
+	}
+}
+
+func (a *asyncWorker[T]) enqueue(v T) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		return ErrClosed
+	}
+
+	it := asyncItem[T]{v: v}
+
+	if a.block {
+		a.queue <- it
+
+		return nil
+	}
+
+	for {
+		select {
+		case a.queue <- it:
+			return nil
+		default:
+		}
+
+		if !a.dropOldest {
+			a.drop()
+
+			return nil
+		}
+
+		// Queue is full, drop the oldest value.
+		select {
+		case old := <-a.queue:
+			// Sync of dropped item is answered after the new value, so that it still waits for earlier values.
+			it.syncs = append(it.syncs, old.syncs...)
+
+			if !old.marker {
+				a.drop()
+			}
+		default:
+		}
+	}
+}
+
+func (a *asyncWorker[T]) drop() {
+	a.dropped.Add(1)
+	a.metrics.drop()
+}
+
+// sync waits for queued values to be handled and calls flush.
+func (a *asyncWorker[T]) sync() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		return ErrClosed
+	}
+
+	res := make(chan error, 1)
+	a.queue <- asyncItem[T]{marker: true, syncs: []chan error{res}}
+
+	return <-res
+}
+
+// close waits for queued values to be handled and stops worker, it returns false if worker is already closed.
+func (a *asyncWorker[T]) close() bool {
+	a.mu.Lock()
+
+	if a.closed {
+		a.mu.Unlock()
+
+		return false
+	}
+
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+
+	<-a.done
+
+	return true
+}
+
+type asyncWrite struct {
+	p       []byte
+	level   zapcore.Level
+	leveled bool
+}
+
+// AsyncWriter passes written data to underlying writer in a background goroutine.
+//
+// Writes do not block, data is dropped when queue is full.
+type AsyncWriter struct {
+	*asyncWorker[asyncWrite]
+
+	w io.Writer
+}
+
+var _ zapcore.WriteSyncer = &AsyncWriter{}
+
+// NewAsyncWriter creates AsyncWriter with a queue of size writes.
+func NewAsyncWriter(w io.Writer, size int) *AsyncWriter {
+	a := &AsyncWriter{w: w}
+	a.asyncWorker = newAsyncWorker(size, a.write, func() error { return syncWriter(w) })
+
+	return a
+}
+
+func (a *AsyncWriter) write(wr asyncWrite) {
+	if wr.leveled {
+		_ = writeLevel(a.w, wr.level, wr.p) //nolint:errcheck // Asynchronous write errors are not reported.
+
+		return
+	}
+
+	_, _ = a.w.Write(wr.p) //nolint:errcheck // Asynchronous write errors are not reported.
+}
+
+// Write enqueues a copy of p for writing.
+func (a *AsyncWriter) Write(p []byte) (int, error) {
+	if err := a.enqueue(asyncWrite{p: append([]byte(nil), p...)}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// WriteLevel enqueues a copy of p for writing with level, so that level filters of underlying writer apply.
+func (a *AsyncWriter) WriteLevel(level zapcore.Level, p []byte) error {
+	return a.enqueue(asyncWrite{p: append([]byte(nil), p...), level: level, leveled: true})
+}
+
+// Dropped returns number of writes dropped due to full queue.
+func (a *AsyncWriter) Dropped() int64 {
+	return a.dropped.Load()
+}
+
+// Sync waits for queued writes to complete and syncs underlying writer.
+func (a *AsyncWriter) Sync() error {
+	return a.sync()
+}
+
+// Close waits for queued writes to complete and closes underlying writer if it implements io.Closer.
+func (a *AsyncWriter) Close() error {
+	if !a.close() {
+		return nil
+	}
+
+	if c, ok := a.w.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+func syncWriter(w io.Writer) error {
+	if s, ok := w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package zapctxd_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestAsyncWriter(t *testing.T) {
+	w := &syncBuffer{}
+	aw := zapctxd.NewAsyncWriter(w, 10)
+
+	for i := 0; i < 5; i++ {
+		_, err := aw.Write([]byte("hello\n"))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, aw.Sync())
+	assert.Equal(t, "hello\nhello\nhello\nhello\nhello\n", w.String())
+	assert.Equal(t, int64(0), aw.Dropped())
+
+	require.NoError(t, aw.Close())
+	require.NoError(t, aw.Close())
+
+	_, err := aw.Write([]byte("hello\n"))
+	assert.ErrorIs(t, err, zapctxd.ErrClosed)
+}
+
+type blockingWriter struct {
+	syncBuffer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	select {
+	case w.started <- struct{}{}:
+		<-w.release
+	default:
+	}
+
+	return w.syncBuffer.Write(p)
+}
+
+func TestConfig_AsyncBuffer(t *testing.T) {
+	w := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:   true,
+		Output:      w,
+		AsyncBuffer: 3,
+	})
+
+	ctx := context.Background()
+
+	c.Info(ctx, "hello", "i", 0)
+	<-w.started // Writer is blocked with the first entry.
+
+	for i := 1; i <= 10; i++ {
+		c.Info(ctx, "hello", "i", i)
+	}
+
+	close(w.release)
+	require.NoError(t, c.Sync())
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","i":0}
+{"level":"info","time":"<stripped>","msg":"hello","i":8}
+{"level":"info","time":"<stripped>","msg":"hello","i":9}
+{"level":"info","time":"<stripped>","msg":"hello","i":10}
+`, w.String())
+	assert.Equal(t, int64(7), c.Metrics().DroppedEntries)
+
+	require.NoError(t, c.Close())
+}
+
+func TestConfig_AsyncBuffer_sync(t *testing.T) {
+	w := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:   true,
+		Output:      w,
+		AsyncBuffer: 1,
+	})
+
+	ctx := context.Background()
+
+	c.Info(ctx, "hello", "i", 0)
+	<-w.started // Writer is blocked with the first entry.
+
+	synced := make(chan error, 1)
+
+	go func() {
+		synced <- c.Sync()
+	}()
+
+	time.Sleep(10 * time.Millisecond) // Sync marker fills the buffer.
+
+	c.Info(ctx, "hello", "i", 1) // Sync marker is pushed out of the buffer, but not answered.
+
+	select {
+	case <-synced:
+		t.Fatal("sync returned before buffered entries are written")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(w.release)
+	require.NoError(t, <-synced)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","i":0}
+{"level":"info","time":"<stripped>","msg":"hello","i":1}
+`, w.String())
+	assert.Equal(t, int64(0), c.Metrics().DroppedEntries)
+
+	require.NoError(t, c.Close())
+}
+
+func TestConfig_AsyncBuffer_outputs(t *testing.T) {
+	all := &syncBuffer{}
+	warn := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:       zap.DebugLevel,
+		StripTime:   true,
+		Output:      all,
+		Outputs:     []zapctxd.OutputSpec{{Writer: warn, MinLevel: zap.WarnLevel}},
+		AsyncBuffer: 10,
+	})
+
+	c.Debug(context.Background(), "debug")
+	c.Warn(context.Background(), "warning")
+
+	require.NoError(t, c.Sync())
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"warning"}`+"\n", warn.String())
+	assert.Contains(t, all.String(), `"msg":"debug"`)
+
+	require.NoError(t, c.Close())
+}
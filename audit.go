@@ -0,0 +1,106 @@
+package zapctxd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditRecord is a JSON record written by Logger.WithAuditTrail.
+type AuditRecord struct {
+	Time      string         `json:"time"`
+	Level     string         `json:"level"`
+	Message   string         `json:"msg"`
+	Fields    map[string]any `json:"fields,omitempty"`
+	Signature string         `json:"signature,omitempty"`
+}
+
+// Sign returns hex-encoded HMAC-SHA256 of record without signature.
+func (r AuditRecord) Sign(key []byte) (string, error) {
+	r.Signature = ""
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(b) //nolint:errcheck // Hash writes do not fail.
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// WithAuditTrail returns a logger that additionally writes entries with "audit": true field to w as AuditRecord.
+//
+// Records are signed with Config.AuditHMACKey for tamper detection, w is expected to be append-only.
+// Error is returned if key is empty. Other entries are not affected.
+func (l *Logger) WithAuditTrail(w io.Writer) (*Logger, error) {
+	if len(l.cfg.AuditHMACKey) == 0 {
+		return nil, errors.New("missing Config.AuditHMACKey to sign audit records")
+	}
+
+	var mu sync.Mutex
+
+	return l.withHook(func(e entry) {
+		if !isAudit(e.kv) {
+			return
+		}
+
+		r := AuditRecord{
+			Time:    time.Now().UTC().Format(time.RFC3339Nano),
+			Level:   e.level.String(),
+			Message: e.msg,
+			Fields:  make(map[string]any, len(e.kv)/2),
+		}
+
+		if l.cfg.StripTime {
+			r.Time = "<stripped>"
+		}
+
+		for i := 0; i < len(e.kv)-1; i += 2 {
+			r.Fields[fmt.Sprint(e.kv[i])] = e.kv[i+1]
+		}
+
+		err := writeAudit(&mu, w, r, l.cfg.AuditHMACKey)
+		if err != nil {
+			l.sugared.Warnw("failed to write audit record", "error", err.Error(), "entry_msg", e.msg)
+		}
+	}), nil
+}
+
+func isAudit(kv []any) bool {
+	for i := 0; i < len(kv)-1; i += 2 {
+		if kv[i] == "audit" && kv[i+1] == true {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeAudit(mu *sync.Mutex, w io.Writer, r AuditRecord, key []byte) error {
+	sig, err := r.Sign(key)
+	if err != nil {
+		return err
+	}
+
+	r.Signature = sig
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	_, err = w.Write(append(b, '\n'))
+
+	return err
+}
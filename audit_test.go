@@ -0,0 +1,54 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithAuditTrail(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	audit := bytes.NewBuffer(nil)
+	key := []byte("secret")
+
+	c, err := zapctxd.New(zapctxd.Config{
+		StripTime:    true,
+		Output:       w,
+		AuditHMACKey: key,
+	}).WithAuditTrail(audit)
+	require.NoError(t, err)
+
+	c.Info(context.Background(), "regular", "foo", "bar")
+	c.Warn(context.Background(), "user deleted", "audit", true, "user", "john")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"regular","foo":"bar"}
+{"level":"warn","time":"<stripped>","msg":"user deleted","audit":true,"user":"john"}
+`, w.String())
+
+	var r zapctxd.AuditRecord
+
+	require.NoError(t, json.Unmarshal(audit.Bytes(), &r))
+	assert.Equal(t, "user deleted", r.Message)
+	assert.Equal(t, "warn", r.Level)
+	assert.Equal(t, map[string]any{"audit": true, "user": "john"}, r.Fields)
+
+	sig, err := r.Sign(key)
+	require.NoError(t, err)
+	assert.Equal(t, sig, r.Signature)
+
+	r.Fields["user"] = "jane"
+	sig, err = r.Sign(key)
+	require.NoError(t, err)
+	assert.NotEqual(t, sig, r.Signature)
+}
+
+func TestLogger_WithAuditTrail_noKey(t *testing.T) {
+	_, err := zapctxd.New(zapctxd.Config{}).WithAuditTrail(bytes.NewBuffer(nil))
+	assert.EqualError(t, err, "missing Config.AuditHMACKey to sign audit records")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/bool64/ctxd"
 	"github.com/bool64/zapctxd"
@@ -71,3 +72,140 @@ func BenchmarkCtxLite(b *testing.B) {
 		c.Debug(ctx, "hello!", "bla2", 2, "bla", 1)
 	}
 }
+
+// BenchmarkCtxLiteZ benchmarks zapctxd.Logger performance with strongly typed fields and empty context.
+func BenchmarkCtxLiteZ(b *testing.B) {
+	c := zapctxd.New(zapctxd.Config{
+		Level:  zap.DebugLevel,
+		Output: io.Discard,
+	})
+
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		c.DebugZ(ctx, "hello!", zap.Int("bla2", 2), zap.Int("bla", 1))
+	}
+}
+
+// BenchmarkCtxLiteHost benchmarks zapctxd.Logger performance with host name and process ID fields and empty context.
+func BenchmarkCtxLiteHost(b *testing.B) {
+	c := zapctxd.New(zapctxd.Config{
+		Level:           zap.DebugLevel,
+		Output:          io.Discard,
+		IncludeHostname: true,
+		IncludePID:      true,
+	})
+
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		c.Debug(ctx, "hello!", "bla2", 2, "bla", 1)
+	}
+}
+
+// BenchmarkCtxLiteF benchmarks zapctxd.Logger performance with formatted message and empty context.
+func BenchmarkCtxLiteF(b *testing.B) {
+	c := zapctxd.New(zapctxd.Config{
+		Level:  zap.DebugLevel,
+		Output: io.Discard,
+	})
+
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		c.Debugf(ctx, "hello %d!", 2)
+	}
+}
+
+// stallingWriter discards data and stalls on every 1000th write, like a pipe or a disk under backpressure.
+type stallingWriter struct {
+	n int
+}
+
+func (w *stallingWriter) Write(p []byte) (int, error) {
+	w.n++
+
+	if w.n%1000 == 0 {
+		time.Sleep(100 * time.Microsecond)
+	}
+
+	return len(p), nil
+}
+
+// BenchmarkCtxLiteStalling benchmarks zapctxd.Logger performance with stalling output and empty context.
+// BenchmarkCtxLiteStalling-4   	  409022	      2895 ns/op	         0 dropped/op	     256 B/op	       1 allocs/op.
+func BenchmarkCtxLiteStalling(b *testing.B) {
+	benchmarkStalling(b, 0)
+}
+
+// BenchmarkCtxLiteStallingAsync benchmarks zapctxd.Logger performance with asynchronous stalling output and empty context.
+// BenchmarkCtxLiteStallingAsync-4   	  444070	      2974 ns/op	         0 dropped/op	     353 B/op	       2 allocs/op.
+func BenchmarkCtxLiteStallingAsync(b *testing.B) {
+	benchmarkStalling(b, 10000)
+}
+
+func benchmarkStalling(b *testing.B, asyncBuffer int) {
+	b.Helper()
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:       zap.DebugLevel,
+		Output:      &stallingWriter{},
+		AsyncBuffer: asyncBuffer,
+	})
+
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		c.Debug(ctx, "hello!", "bla2", 2, "bla", 1)
+	}
+
+	b.StopTimer()
+
+	if err := c.Close(); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportMetric(float64(c.Metrics().DroppedEntries)/float64(b.N), "dropped/op")
+}
+
+// BenchmarkContextLoggerPool_Get benchmarks request-scoped logger from pool with context fields.
+// BenchmarkContextLoggerPool_Get-4   	  498687	      2065 ns/op	     536 B/op	       3 allocs/op.
+func BenchmarkContextLoggerPool_Get(b *testing.B) {
+	p := zapctxd.NewContextLoggerPool(zapctxd.New(zapctxd.Config{
+		Level:  zap.DebugLevel,
+		Output: io.Discard,
+	}), 1)
+
+	ctx := ctxd.AddFields(context.Background(), "request_id", "r1", "user", 1)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		l, release := p.Get(ctx)
+		l.Debug(context.Background(), "hello!", "bla", 1)
+		release()
+	}
+}
+
+// BenchmarkCtxLiteWith benchmarks request-scoped logger with context fields encoded into new cores.
+// BenchmarkCtxLiteWith-4   	  295059	      3845 ns/op	    5040 B/op	      18 allocs/op.
+func BenchmarkCtxLiteWith(b *testing.B) {
+	c := zapctxd.New(zapctxd.Config{
+		Level:  zap.DebugLevel,
+		Output: io.Discard,
+	})
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		c.With("request_id", "r1", "user", 1).Debug(context.Background(), "hello!", "bla", 1)
+	}
+}
@@ -0,0 +1,95 @@
+package zapctxd
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// halfOpenInterval is a period of entries allowed by half-open circuit breaker.
+const halfOpenInterval = time.Second
+
+type circuitBreaker struct {
+	maxErrors int
+	window    time.Duration
+
+	mu          sync.Mutex
+	state       int
+	since       time.Time
+	windowStart time.Time
+	errors      int
+	lastAllowed time.Time
+}
+
+// drop counts errors and returns true if entry should be suppressed.
+func (b *circuitBreaker) drop(e entry, now time.Time) bool {
+	if e.level >= zap.FatalLevel {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Sub(b.windowStart) >= b.window {
+		b.windowStart = now
+		b.errors = 0
+	}
+
+	if e.level >= zap.ErrorLevel {
+		b.errors++
+	}
+
+	if b.state == breakerOpen && now.Sub(b.since) >= b.window {
+		b.state = breakerHalfOpen
+		b.since = now
+		b.windowStart = now
+		b.errors = 0
+		b.lastAllowed = time.Time{}
+	}
+
+	if b.errors > b.maxErrors {
+		b.state = breakerOpen
+		b.since = now
+
+		return true
+	}
+
+	switch b.state {
+	case breakerOpen:
+		return true
+	case breakerHalfOpen:
+		if now.Sub(b.since) >= b.window {
+			b.state = breakerClosed
+
+			return false
+		}
+
+		if now.Sub(b.lastAllowed) < halfOpenInterval {
+			return true
+		}
+
+		b.lastAllowed = now
+	}
+
+	return false
+}
+
+// WithCircuitBreaker returns a logger that suppresses entries during error storms.
+//
+// When more than maxErrors entries of Error level are logged within window, circuit opens and
+// all entries except Fatal are dropped for window duration. Then circuit is half-open and allows one
+// entry per second, it closes if error rate stays within limit for window or opens again otherwise.
+func (l *Logger) WithCircuitBreaker(maxErrors int, window time.Duration) *Logger {
+	b := &circuitBreaker{maxErrors: maxErrors, window: window}
+
+	return l.withFilter(func(e entry) bool {
+		return b.drop(e, time.Now())
+	})
+}
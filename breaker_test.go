@@ -0,0 +1,45 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithCircuitBreaker(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	ctx := context.Background()
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithCircuitBreaker(2, 100*time.Millisecond)
+
+	c.Error(ctx, "e1")
+	c.Error(ctx, "e2")
+	c.Error(ctx, "e3")
+	c.Info(ctx, "suppressed")
+
+	time.Sleep(120 * time.Millisecond)
+
+	c.Info(ctx, "half-open")
+	c.Info(ctx, "suppressed in half-open")
+
+	time.Sleep(120 * time.Millisecond)
+
+	c.Info(ctx, "closed")
+	c.Info(ctx, "closed again")
+
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"e1"}
+{"level":"error","time":"<stripped>","msg":"e2"}
+{"level":"info","time":"<stripped>","msg":"half-open"}
+{"level":"info","time":"<stripped>","msg":"closed"}
+{"level":"info","time":"<stripped>","msg":"closed again"}
+`, w.String())
+	assert.Equal(t, int64(3), c.Metrics().DroppedEntries)
+}
@@ -0,0 +1,36 @@
+package zapctxd
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+)
+
+// WithBuildInfo returns a logger that adds build information of running binary to every entry.
+//
+// Fields are "go_version", "module_version" of main module, "vcs_revision" and "vcs_dirty",
+// fields that are not available in build information are omitted.
+func (l *Logger) WithBuildInfo() *Logger {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return l.with(zap.String("go_version", runtime.Version()))
+	}
+
+	fields := []zap.Field{zap.String("go_version", bi.GoVersion)}
+
+	if bi.Main.Version != "" {
+		fields = append(fields, zap.String("module_version", bi.Main.Version))
+	}
+
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			fields = append(fields, zap.String("vcs_revision", s.Value))
+		case "vcs.modified":
+			fields = append(fields, zap.Bool("vcs_dirty", s.Value == "true"))
+		}
+	}
+
+	return l.with(fields...)
+}
@@ -0,0 +1,23 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithBuildInfo(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithBuildInfo().Info(context.Background(), "hello")
+
+	assert.Contains(t, w.String(), `"go_version":"`+runtime.Version()+`"`)
+}
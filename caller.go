@@ -0,0 +1,73 @@
+package zapctxd
+
+import (
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const packagePrefix = "github.com/bool64/zapctxd."
+
+// WithCallerFunc returns a logger that adds "caller_func" field with short name of calling function,
+// e.g. "main.handleRequest".
+//
+// It is independent of caller encoder, calls within this package are skipped.
+func (l *Logger) WithCallerFunc() *Logger {
+	return l.withProcessor(func(e entry) []any {
+		return append(e.kv, "caller_func", callerFunc())
+	})
+}
+
+func callerFunc() string {
+	var pcs [32]uintptr
+
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+
+	for {
+		f, more := frames.Next()
+
+		if !strings.HasPrefix(f.Function, packagePrefix) {
+			return f.Function[strings.LastIndex(f.Function, "/")+1:]
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// WithCaller returns a logger that adds caller of logging method to entries, e.g. in JSON output
+// of production mode.
+//
+// Additional skip frames can be set to report caller of a wrapper, further Logger.SkipCaller
+// calls increase skip count. Logger with enabled caller is only adjusted by skip.
+func (l *Logger) WithCaller(skip int) *Logger {
+	nl := *l
+	nl.callerFrames += skip
+
+	var opts []zap.Option
+
+	if !l.caller {
+		opts = append(opts, zap.AddCaller())
+	}
+
+	// Frame of Logger method is skipped once.
+	if !l.callerSkip {
+		skip++
+	}
+
+	if skip != 0 {
+		opts = append(opts, zap.AddCallerSkip(skip))
+	}
+
+	nl.caller = true
+	nl.callerSkip = true
+	nl.options = append(l.options[:len(l.options):len(l.options)], opts...)
+	nl.zl = l.zl.WithOptions(opts...)
+	nl.zdebug = l.zdebug.WithOptions(opts...)
+	nl.sugared = nl.zl.Sugar()
+	nl.debug = nl.zdebug.Sugar()
+
+	return &nl
+}
@@ -0,0 +1,81 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithCallerFunc(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithCallerFunc()
+
+	c.Info(context.Background(), "hello")
+
+	func() {
+		c.Warn(context.Background(), "nested")
+	}()
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","caller_func":"zapctxd_test.TestLogger_WithCallerFunc"}
+{"level":"warn","time":"<stripped>","msg":"nested","caller_func":"zapctxd_test.TestLogger_WithCallerFunc.func1"}
+`, w.String())
+}
+
+func TestLogger_WithCaller(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithCaller(0)
+
+	wrapper := func(c *zapctxd.Logger) {
+		c.WithCaller(1).Info(context.Background(), "wrapped")
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+
+	c.Info(context.Background(), "hello")
+	wrapper(c)
+
+	assert.Equal(t, fmt.Sprintf(`{"level":"info","time":"<stripped>","caller":"zapctxd/caller_test.go:%d","msg":"hello"}
+{"level":"info","time":"<stripped>","caller":"zapctxd/caller_test.go:%d","msg":"wrapped"}
+`, line+2, line+3), w.String())
+
+	w.Reset()
+
+	wrapper(zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		DevMode:   true,
+		Output:    w,
+	}))
+
+	assert.Equal(t, fmt.Sprintf("<stripped>\tINFO\tzapctxd/caller_test.go:%d\twrapped\n", line+11), w.String())
+}
+
+func TestLogger_WithCaller_errorStackTrace(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:       true,
+		ErrorStackTrace: true,
+		Output:          w,
+	}).WithCaller(0)
+
+	_, _, line, _ := runtime.Caller(0)
+
+	c.Info(context.Background(), "hello")
+
+	assert.Equal(t, fmt.Sprintf(`{"level":"info","time":"<stripped>","caller":"zapctxd/caller_test.go:%d","msg":"hello"}
+`, line+2), w.String())
+}
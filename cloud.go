@@ -0,0 +1,244 @@
+package zapctxd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Cloud providers for Logger.WithCloud.
+const (
+	CloudGCP   = "gcp"
+	CloudAWS   = "aws"
+	CloudAzure = "azure"
+	CloudAuto  = "auto"
+)
+
+const cloudMetadataTimeout = 300 * time.Millisecond
+
+type cloudProfile struct {
+	levelKey    string
+	messageKey  string
+	timeKey     string
+	encodeLevel zapcore.LevelEncoder
+
+	projectEnv []string
+	projectKey string
+	regionEnv  []string
+	region     func(ctx context.Context) string
+}
+
+var cloudProfiles = map[string]cloudProfile{
+	CloudGCP: {
+		levelKey:    "severity",
+		messageKey:  "message",
+		timeKey:     "time",
+		encodeLevel: gcpLevelEncoder,
+		projectEnv:  []string{"GOOGLE_CLOUD_PROJECT", "GCP_PROJECT", "GCLOUD_PROJECT"},
+		projectKey:  "project_id",
+		regionEnv:   []string{"GOOGLE_CLOUD_REGION", "FUNCTION_REGION", "CLOUD_RUN_REGION"},
+		region:      gcpRegion,
+	},
+	CloudAWS: {
+		levelKey:    "level",
+		messageKey:  "message",
+		timeKey:     "timestamp",
+		encodeLevel: zapcore.CapitalLevelEncoder,
+		projectEnv:  []string{"AWS_ACCOUNT_ID"},
+		projectKey:  "account_id",
+		regionEnv:   []string{"AWS_REGION", "AWS_DEFAULT_REGION"},
+		region:      awsRegion,
+	},
+	CloudAzure: {
+		levelKey:    "severityLevel",
+		messageKey:  "message",
+		timeKey:     "time",
+		encodeLevel: zapcore.CapitalLevelEncoder,
+		projectEnv:  []string{"AZURE_SUBSCRIPTION_ID"},
+		projectKey:  "subscription_id",
+		regionEnv:   []string{"AZURE_REGION", "REGION_NAME"},
+		region:      azureRegion,
+	},
+}
+
+// WithCloud returns a copy of logger configured for log ingestion of a cloud provider.
+//
+// Provider can be "gcp", "aws", "azure" or "auto" to detect provider by environment variables.
+// Field names and time format are adjusted to provider conventions, project (or account) and region
+// are added to every entry, region is taken from environment or from instance metadata service.
+// If provider is unknown or can not be detected, logger is returned unchanged.
+func (l *Logger) WithCloud(provider string) *Logger {
+	if provider == CloudAuto {
+		provider = detectCloud()
+	}
+
+	p, ok := cloudProfiles[provider]
+
+	if !ok || l.mu == nil {
+		if provider != "" && !ok {
+			if l.devMode {
+				panic(fmt.Sprintf("unknown cloud provider %q", provider))
+			}
+
+			l.sugared.Warnw("unknown cloud provider", "provider", provider)
+		}
+
+		return l
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	nl := *l
+
+	nl.mu = &sync.RWMutex{}
+	nl.encoderOptions = append(l.encoderOptions[:len(l.encoderOptions):len(l.encoderOptions)], p.configure(l.cfg))
+	nl.format = encodingJSON
+	nl.encoder = newEncoder(l.cfg, encodingJSON, nl.encoderOptions...)
+	nl.make()
+
+	var fields []zap.Field
+
+	if project := firstEnv(p.projectEnv...); project != "" {
+		fields = append(fields, zap.String(p.projectKey, project))
+	}
+
+	region := firstEnv(p.regionEnv...)
+	if region == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), cloudMetadataTimeout)
+		region = p.region(ctx)
+
+		cancel()
+	}
+
+	if region != "" {
+		fields = append(fields, zap.String("region", region))
+	}
+
+	if len(fields) == 0 {
+		return &nl
+	}
+
+	return nl.with(fields...)
+}
+
+func (p cloudProfile) configure(cfg Config) func(ec *zapcore.EncoderConfig) {
+	return func(ec *zapcore.EncoderConfig) {
+		ec.LevelKey = p.levelKey
+		ec.MessageKey = p.messageKey
+		ec.TimeKey = p.timeKey
+		ec.EncodeLevel = p.encodeLevel
+
+		if !cfg.StripTime {
+			ec.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+		}
+	}
+}
+
+func detectCloud() string {
+	switch {
+	case firstEnv("GOOGLE_CLOUD_PROJECT", "GCP_PROJECT", "K_SERVICE", "FUNCTION_TARGET") != "":
+		return CloudGCP
+	case firstEnv("AWS_REGION", "AWS_DEFAULT_REGION", "AWS_LAMBDA_FUNCTION_NAME", "AWS_EXECUTION_ENV") != "":
+		return CloudAWS
+	case firstEnv("AZURE_SUBSCRIPTION_ID", "WEBSITE_SITE_NAME", "AZURE_REGION") != "":
+		return CloudAzure
+	}
+
+	return ""
+}
+
+func firstEnv(names ...string) string {
+	for _, n := range names {
+		if v := os.Getenv(n); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// gcpLevelEncoder encodes levels as Cloud Logging severities.
+func gcpLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch l {
+	case zapcore.DebugLevel:
+		enc.AppendString("DEBUG")
+	case zapcore.InfoLevel:
+		enc.AppendString("INFO")
+	case zapcore.WarnLevel:
+		enc.AppendString("WARNING")
+	case zapcore.ErrorLevel:
+		enc.AppendString("ERROR")
+	case zapcore.DPanicLevel:
+		enc.AppendString("CRITICAL")
+	case zapcore.PanicLevel:
+		enc.AppendString("ALERT")
+	case zapcore.FatalLevel:
+		enc.AppendString("EMERGENCY")
+	default:
+		enc.AppendString("DEFAULT")
+	}
+}
+
+func gcpRegion(ctx context.Context) string {
+	// Region is returned as projects/<number>/regions/<region>.
+	r := metadata(ctx, http.MethodGet, "http://metadata.google.internal/computeMetadata/v1/instance/region",
+		map[string]string{"Metadata-Flavor": "Google"})
+
+	return r[strings.LastIndex(r, "/")+1:]
+}
+
+func awsRegion(ctx context.Context) string {
+	token := metadata(ctx, http.MethodPut, "http://169.254.169.254/latest/api/token",
+		map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "60"})
+	if token == "" {
+		return ""
+	}
+
+	return metadata(ctx, http.MethodGet, "http://169.254.169.254/latest/meta-data/placement/region",
+		map[string]string{"X-aws-ec2-metadata-token": token})
+}
+
+func azureRegion(ctx context.Context) string {
+	return metadata(ctx, http.MethodGet,
+		"http://169.254.169.254/metadata/instance/compute/location?api-version=2021-02-01&format=text",
+		map[string]string{"Metadata": "true"})
+}
+
+// metadata requests instance metadata service, it returns empty string on failure.
+func metadata(ctx context.Context, method, url string, header map[string]string) string {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return ""
+	}
+
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return ""
+	}
+
+	defer resp.Body.Close() //nolint:errcheck // Close error is not actionable.
+
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
@@ -0,0 +1,46 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithCloud(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+	t.Setenv("GOOGLE_CLOUD_REGION", "us-central1")
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	c.WithCloud("auto").Warn(context.Background(), "hello", "foo", "bar")
+	c.Warn(context.Background(), "plain")
+
+	assert.Equal(t, `{"severity":"WARNING","time":"<stripped>","message":"hello","project_id":"my-project","region":"us-central1","foo":"bar"}
+{"level":"warn","time":"<stripped>","msg":"plain"}
+`, w.String())
+}
+
+func TestLogger_WithCloud_aws(t *testing.T) {
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithCloud(zapctxd.CloudAWS)
+
+	c.Error(context.Background(), "hello")
+
+	assert.Equal(t, `{"level":"ERROR","timestamp":"<stripped>","message":"hello","region":"eu-west-1"}
+`, w.String())
+}
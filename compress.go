@@ -0,0 +1,116 @@
+package zapctxd
+
+import (
+	"compress/gzip"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// gzipWriter compresses data written to underlying writer and flushes it periodically.
+type gzipWriter struct {
+	ws   zapcore.WriteSyncer
+	done chan struct{}
+
+	mu     sync.Mutex
+	gz     *gzip.Writer
+	closed bool
+}
+
+func newGzipWriter(ws zapcore.WriteSyncer, level int, interval time.Duration) (*gzipWriter, error) {
+	gz, err := gzip.NewWriterLevel(ws, level)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &gzipWriter{ws: ws, gz: gz, done: make(chan struct{})}
+
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-t.C:
+				_ = w.Sync() //nolint:errcheck // Flush errors are not actionable in background.
+			}
+		}
+	}()
+
+	return w, nil
+}
+
+func (w *gzipWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrClosed
+	}
+
+	return w.gz.Write(p)
+}
+
+// Sync flushes compressed data to make it available for readers of output without closing the stream.
+func (w *gzipWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	return multierr.Append(w.gz.Flush(), w.ws.Sync())
+}
+
+// Close finishes compressed stream.
+func (w *gzipWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+	close(w.done)
+
+	return multierr.Append(w.gz.Close(), w.ws.Sync())
+}
+
+// WithCompression returns a clone of logger that writes gzip-compressed output with compression level 0-9.
+//
+// Compressed data is flushed every Config.FlushInterval (default 1s) and on Sync, so that recent entries
+// are available to log shippers, Close of the clone finishes the stream.
+// Level filters of Config.Outputs do not apply to compressed stream, it receives entries of all levels.
+// Logger created with zap loggers is returned unchanged.
+func (l *Logger) WithCompression(level int) *Logger {
+	if l.mu == nil {
+		return l
+	}
+
+	interval := l.cfg.FlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	w, err := newGzipWriter(l.out, level, interval)
+	if err != nil {
+		if l.devMode {
+			panic(fmt.Sprintf("invalid compression level %d: %v", level, err))
+		}
+
+		l.sugared.Warnw("invalid compression level", "level", level, "error", err.Error())
+
+		return l
+	}
+
+	nl, _ := l.withOutput(w, w.Close) //nolint:errcheck // Logger has mutex.
+
+	return nl
+}
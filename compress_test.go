@@ -0,0 +1,51 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithCompression(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:     true,
+		Output:        w,
+		FlushInterval: 10 * time.Millisecond,
+	}).WithCompression(gzip.BestCompression)
+
+	c.Info(context.Background(), "hello")
+
+	// Periodic flush makes entry available without closing the stream.
+	assert.Eventually(t, func() bool {
+		r, err := gzip.NewReader(bytes.NewReader([]byte(w.String())))
+		if err != nil {
+			return false
+		}
+
+		b, _ := io.ReadAll(r) //nolint:errcheck // Stream is not finished yet.
+
+		return string(b) == `{"level":"info","time":"<stripped>","msg":"hello"}`+"\n"
+	}, time.Second, 10*time.Millisecond)
+
+	c.Info(context.Background(), "world")
+	require.NoError(t, c.Close())
+
+	r, err := gzip.NewReader(bytes.NewReader([]byte(w.String())))
+	require.NoError(t, err)
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello"}
+{"level":"info","time":"<stripped>","msg":"world"}
+`, string(b))
+}
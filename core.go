@@ -0,0 +1,107 @@
+package zapctxd
+
+import (
+	"io"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// levelWriter is implemented by outputs that need entry level along with encoded entry.
+type levelWriter interface {
+	zapcore.WriteSyncer
+	WriteLevel(level zapcore.Level, p []byte) error
+}
+
+// writeLevel writes p with level if w supports it.
+func writeLevel(w io.Writer, level zapcore.Level, p []byte) error {
+	if lw, ok := w.(interface {
+		WriteLevel(level zapcore.Level, p []byte) error
+	}); ok {
+		return lw.WriteLevel(level, p)
+	}
+
+	_, err := w.Write(p)
+
+	return err
+}
+
+// unownedOutput hides io.Closer of output that is not owned by wrapper, levels of writes are kept.
+type unownedOutput struct {
+	zapcore.WriteSyncer
+}
+
+func (o unownedOutput) WriteLevel(level zapcore.Level, p []byte) error {
+	return writeLevel(o.WriteSyncer, level, p)
+}
+
+func newCore(enc zapcore.Encoder, ws zapcore.WriteSyncer, enab zapcore.LevelEnabler) zapcore.Core {
+	if lw, ok := ws.(levelWriter); ok {
+		return &levelCore{LevelEnabler: enab, enc: enc, out: lw}
+	}
+
+	return zapcore.NewCore(enc, ws, enab)
+}
+
+// levelCore is similar to zapcore.NewCore, but passes entry level to output.
+type levelCore struct {
+	zapcore.LevelEnabler
+
+	enc zapcore.Encoder
+	out levelWriter
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	return &levelCore{LevelEnabler: c.LevelEnabler, enc: enc, out: c.out}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+func (c *levelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+
+	err = c.out.WriteLevel(ent.Level, buf.Bytes())
+
+	buf.Free()
+
+	if err != nil {
+		return err
+	}
+
+	if ent.Level > zapcore.ErrorLevel {
+		// Sync on panic and fatal levels, same as zapcore.ioCore does.
+		return c.Sync()
+	}
+
+	return nil
+}
+
+func (c *levelCore) Sync() error {
+	return c.out.Sync()
+}
+
+// checkWrapper adds wrapper core to checked entry if inner core accepts entry.
+//
+// Wrappers that intercept Write can not add inner core directly, but inner Check is
+// still consulted, so that sampling and other decisions of inner core are kept.
+func checkWrapper(inner, wrapper zapcore.Core, ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if inner.Check(ent, nil) == nil {
+		return ce
+	}
+
+	return ce.AddCore(ent, wrapper)
+}
@@ -0,0 +1,165 @@
+package zapctxd
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	costWindow           = 24 * time.Hour
+	costMinProjectionAge = time.Hour
+)
+
+// CostBudget limits daily volume of written log data.
+type CostBudget struct {
+	// MaxDailyBytes is a maximum number of bytes written in a day.
+	MaxDailyBytes int64
+
+	mu     sync.Mutex
+	start  time.Time
+	bytes  int64
+	levels [zapcore.FatalLevel - zapcore.DebugLevel + 1]int64
+}
+
+// CurrentBytes returns number of bytes written in current window.
+func (b *CostBudget) CurrentBytes() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rotate(time.Now())
+
+	return b.bytes
+}
+
+// LevelBytes returns number of bytes written with level in current window.
+func (b *CostBudget) LevelBytes(level zapcore.Level) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rotate(time.Now())
+
+	if level < zapcore.DebugLevel || level > zapcore.FatalLevel {
+		return 0
+	}
+
+	return b.levels[level-zapcore.DebugLevel]
+}
+
+// rotate resets counters when window is over, it must be called with lock held.
+func (b *CostBudget) rotate(now time.Time) {
+	if now.Sub(b.start) < costWindow {
+		return
+	}
+
+	b.start = now
+	b.bytes = 0
+	b.levels = [len(b.levels)]int64{}
+}
+
+func (b *CostBudget) add(level zapcore.Level, n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rotate(time.Now())
+
+	b.bytes += int64(n)
+
+	if level >= zapcore.DebugLevel && level <= zapcore.FatalLevel {
+		b.levels[level-zapcore.DebugLevel] += int64(n)
+	}
+}
+
+// suppresses tells if entries of level should be dropped to stay within budget.
+//
+// Debug entries are dropped when daily volume projected from current rate exceeds budget,
+// info entries are dropped when budget is exhausted.
+func (b *CostBudget) suppresses(level zapcore.Level) bool {
+	if level > zap.InfoLevel {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.rotate(now)
+
+	if b.bytes > b.MaxDailyBytes {
+		return true
+	}
+
+	if level > zap.DebugLevel {
+		return false
+	}
+
+	// Projection is smoothed for a fresh window to avoid reacting on startup bursts.
+	elapsed := now.Sub(b.start)
+	if elapsed < costMinProjectionAge {
+		elapsed = costMinProjectionAge
+	}
+
+	return float64(b.bytes)*float64(costWindow)/float64(elapsed) > float64(b.MaxDailyBytes)
+}
+
+// WithCostTracker returns a clone of logger that counts written bytes in budget and suppresses
+// debug and then info entries when budget is at risk.
+//
+// Logger created with zap loggers is returned unchanged.
+func (l *Logger) WithCostTracker(budget *CostBudget) *Logger {
+	if l.mu == nil {
+		return l
+	}
+
+	nl, err := l.withOutput(&costWriter{ws: l.out, budget: budget}, nil)
+	if err != nil {
+		return l
+	}
+
+	return nl.withFilter(func(e entry) bool {
+		if e.important {
+			return false
+		}
+
+		return budget.suppresses(e.level)
+	})
+}
+
+// costWriter counts bytes written to underlying output.
+type costWriter struct {
+	ws     zapcore.WriteSyncer
+	budget *CostBudget
+}
+
+func (w *costWriter) Write(p []byte) (int, error) {
+	return w.write(zapcore.InfoLevel, p)
+}
+
+func (w *costWriter) WriteLevel(level zapcore.Level, p []byte) error {
+	_, err := w.write(level, p)
+
+	return err
+}
+
+func (w *costWriter) write(level zapcore.Level, p []byte) (int, error) {
+	var (
+		n   int
+		err error
+	)
+
+	if lw, ok := w.ws.(levelWriter); ok {
+		n, err = len(p), lw.WriteLevel(level, p)
+	} else {
+		n, err = w.ws.Write(p)
+	}
+
+	w.budget.add(level, n)
+
+	return n, err
+}
+
+func (w *costWriter) Sync() error {
+	return w.ws.Sync()
+}
@@ -0,0 +1,48 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithCostTracker(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	budget := &zapctxd.CostBudget{MaxDailyBytes: 1200}
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.DebugLevel,
+		StripTime: true,
+		Output:    w,
+	}).WithCostTracker(budget)
+
+	ctx := context.Background()
+
+	c.Debug(ctx, "first debug")
+	c.Debug(ctx, "second debug")
+
+	line := `{"level":"debug","time":"<stripped>","msg":"first debug"}` + "\n"
+	assert.Equal(t, line, w.String())
+	assert.Equal(t, int64(len(line)), budget.CurrentBytes())
+	assert.Equal(t, int64(len(line)), budget.LevelBytes(zap.DebugLevel))
+
+	for i := 0; i < 100; i++ {
+		c.Info(ctx, "info")
+	}
+
+	c.Important(ctx, "important")
+	c.Warn(ctx, "warning")
+
+	// Info entries are written until budget is exhausted.
+	infoLen := len(`{"level":"info","time":"<stripped>","msg":"info"}` + "\n")
+	assert.Equal(t, (1200-len(line))/infoLen+1, strings.Count(w.String(), `"msg":"info"`))
+	assert.Contains(t, w.String(), `"msg":"important"`)
+	assert.Contains(t, w.String(), `"msg":"warning"`)
+	assert.Greater(t, budget.CurrentBytes(), int64(1200))
+}
@@ -0,0 +1,51 @@
+package zapctxd
+
+import (
+	"context"
+	"time"
+
+	"github.com/bool64/ctxd"
+)
+
+// WithContextTimeout returns a logger that limits extraction of context fields to timeout.
+//
+// If fields are not extracted in time, entry is written without context fields and with "ctx_timeout": true.
+// It is a safety valve against pathological contexts, extraction is done in a separate goroutine.
+func (l *Logger) WithContextTimeout(timeout time.Duration) *Logger {
+	nl := *l
+
+	nl.ctxTimeout = timeout
+
+	return &nl
+}
+
+// contextFields returns fields of context and true if extraction timed out.
+func (l *Logger) contextFields(ctx context.Context) ([]any, bool) {
+	if l.ctxTimeout <= 0 {
+		return l.extractFields(ctx), false
+	}
+
+	done := make(chan []any, 1)
+
+	go func() {
+		done <- l.extractFields(ctx)
+	}()
+
+	t := time.NewTimer(l.ctxTimeout)
+	defer t.Stop()
+
+	select {
+	case fv := <-done:
+		return fv, false
+	case <-t.C:
+		return nil, true
+	}
+}
+
+func (l *Logger) extractFields(ctx context.Context) []any {
+	if l.fieldCache != nil {
+		return l.fieldCache.fields(ctx)
+	}
+
+	return ctxd.Fields(ctx)
+}
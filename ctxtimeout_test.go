@@ -0,0 +1,42 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+type slowContext struct {
+	context.Context
+	delay time.Duration
+}
+
+func (c slowContext) Value(key any) any {
+	time.Sleep(c.delay)
+
+	return c.Context.Value(key)
+}
+
+func TestLogger_WithContextTimeout(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithContextTimeout(20 * time.Millisecond)
+
+	ctx := ctxd.AddFields(context.Background(), "user", "john")
+
+	c.Info(ctx, "fast", "foo", "bar")
+	c.Info(slowContext{Context: ctx, delay: 100 * time.Millisecond}, "slow", "foo", "bar")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"fast","foo":"bar","user":"john"}
+{"level":"info","time":"<stripped>","msg":"slow","foo":"bar","ctx_timeout":true}
+`, w.String())
+}
@@ -0,0 +1,89 @@
+package zapctxd
+
+import "go.uber.org/zap"
+
+// dedupMapThreshold is a number of key-value items starting from which keys are looked up with a map.
+const dedupMapThreshold = 16
+
+// mergeFields merges call-site and context key-value pairs, pairs with keys that exist in the other source
+// are dropped from context or from call-site with Config.ContextFieldPriority.
+func (l *Logger) mergeFields(callSite, ctxFields []any) []any {
+	kv := make([]any, 0, len(callSite)+len(ctxFields))
+
+	if l.cfg.ContextFieldPriority {
+		kv = appendUnique(kv, callSite, keyLookup(ctxFields))
+
+		return append(kv, ctxFields...)
+	}
+
+	kv = append(kv, callSite...)
+
+	return appendUnique(kv, ctxFields, keyLookup(callSite))
+}
+
+// appendUnique appends to dst pairs of src with keys that are not found by exists, zap fields and
+// Priority pairs are appended as is.
+func appendUnique(dst, src []any, exists func(key string) bool) []any {
+	for i := 0; i < len(src); i++ {
+		switch src[i].(type) {
+		case zap.Field, PriorityField:
+			dst = append(dst, src[i])
+
+			continue
+		}
+
+		if i == len(src)-1 {
+			return append(dst, src[i])
+		}
+
+		if k, ok := src[i].(string); !ok || !exists(k) {
+			dst = append(dst, src[i], src[i+1])
+		}
+
+		i++
+	}
+
+	return dst
+}
+
+// keyLookup returns a function that checks if key exists in key-value pairs.
+func keyLookup(kv []any) func(key string) bool {
+	var keys []string
+
+	for i := 0; i < len(kv)-1; i++ {
+		switch kv[i].(type) {
+		case zap.Field, PriorityField:
+			continue
+		}
+
+		if k, ok := kv[i].(string); ok {
+			keys = append(keys, k)
+		}
+
+		i++
+	}
+
+	if len(kv) < dedupMapThreshold {
+		return func(key string) bool {
+			for _, k := range keys {
+				if k == key {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	m := make(map[string]struct{}, len(keys))
+
+	for _, k := range keys {
+		m[k] = struct{}{}
+	}
+
+	return func(key string) bool {
+		_, ok := m[key]
+
+		return ok
+	}
+}
@@ -0,0 +1,110 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestConfig_DeduplicateFields(t *testing.T) {
+	ctx := ctxd.AddFields(context.Background(), "user", "ctx-user", "role", "ctx-role")
+
+	for _, tc := range []struct {
+		name     string
+		ctxFirst bool
+		expected string
+	}{
+		{
+			name:     "call-site wins",
+			expected: `{"level":"info","time":"<stripped>","msg":"hello","user":"call-user","foo":"bar","role":"ctx-role"}`,
+		},
+		{
+			name:     "context wins",
+			ctxFirst: true,
+			expected: `{"level":"info","time":"<stripped>","msg":"hello","foo":"bar","user":"ctx-user","role":"ctx-role"}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := bytes.NewBuffer(nil)
+
+			c := zapctxd.New(zapctxd.Config{
+				StripTime:            true,
+				Output:               w,
+				DeduplicateFields:    true,
+				ContextFieldPriority: tc.ctxFirst,
+			})
+
+			c.Info(ctx, "hello", "user", "call-user", "foo", "bar")
+
+			assert.Equal(t, tc.expected+"\n", w.String())
+		})
+	}
+}
+
+func TestConfig_DeduplicateFields_large(t *testing.T) {
+	var callSite, ctxFields []any
+
+	for i := 0; i < 20; i++ {
+		callSite = append(callSite, "k"+strconv.Itoa(i), "call")
+		ctxFields = append(ctxFields, "k"+strconv.Itoa(i+10), "ctx")
+	}
+
+	ctx := ctxd.AddFields(context.Background(), ctxFields...)
+
+	for _, ctxFirst := range []bool{false, true} {
+		w := bytes.NewBuffer(nil)
+
+		c := zapctxd.New(zapctxd.Config{
+			Output:               w,
+			DeduplicateFields:    true,
+			ContextFieldPriority: ctxFirst,
+		})
+
+		c.Info(ctx, "hello", callSite...)
+
+		var e map[string]any
+
+		require.NoError(t, json.Unmarshal(w.Bytes(), &e))
+
+		for i := 0; i < 30; i++ {
+			k := "k" + strconv.Itoa(i)
+			assert.Equal(t, 1, strings.Count(w.String(), `"`+k+`":`), k)
+
+			expected := "call"
+			if i >= 20 || (ctxFirst && i >= 10) {
+				expected = "ctx"
+			}
+
+			assert.Equal(t, expected, e[k], k)
+		}
+	}
+}
+
+func TestConfig_DeduplicateFields_typed(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:         true,
+		Output:            w,
+		DeduplicateFields: true,
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "user", "ctx-user", "role", "ctx-role")
+
+	c.InfoZ(ctx, "hello", zap.String("user", "call-user"), zap.Int("foo", 1))
+	c.WarnZ(ctx, "warn", zap.String("role", "call-role"))
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","user":"call-user","foo":1,"role":"ctx-role"}
+{"level":"warn","time":"<stripped>","msg":"warn","role":"call-role","user":"ctx-user"}
+`, w.String())
+}
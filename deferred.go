@@ -0,0 +1,131 @@
+package zapctxd
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxDeferredEntries is a capacity of deferred logger buffer.
+const maxDeferredEntries = 1000
+
+type deferredWriter struct {
+	ws       zapcore.WriteSyncer
+	overflow func()
+
+	mu       sync.Mutex
+	buf      [][]byte
+	overflew bool
+}
+
+func (d *deferredWriter) Write(p []byte) (int, error) {
+	d.mu.Lock()
+
+	if !d.overflew && len(d.buf) < maxDeferredEntries {
+		d.buf = append(d.buf, append([]byte(nil), p...))
+		d.mu.Unlock()
+
+		return len(p), nil
+	}
+
+	var pending [][]byte
+
+	if !d.overflew {
+		d.overflew = true
+		pending = d.buf
+		d.buf = nil
+	}
+
+	d.mu.Unlock()
+
+	if pending != nil {
+		if err := d.writeAll(context.Background(), pending); err != nil {
+			return 0, err
+		}
+
+		d.overflow()
+	}
+
+	return d.ws.Write(p)
+}
+
+func (d *deferredWriter) Sync() error {
+	d.mu.Lock()
+	overflew := d.overflew
+	d.mu.Unlock()
+
+	if !overflew {
+		return nil
+	}
+
+	return d.ws.Sync()
+}
+
+func (d *deferredWriter) writeAll(ctx context.Context, entries [][]byte) error {
+	for _, p := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if _, err := d.ws.Write(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *deferredWriter) take() [][]byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries := d.buf
+	d.buf = nil
+	d.overflew = false
+
+	return entries
+}
+
+// Deferred returns a clone of logger that buffers entries in memory until Logger.Flush or Logger.Discard is called.
+//
+// It allows logging consistent with transactions, e.g. only when database transaction is committed.
+// When buffer exceeds 1000 entries, buffered entries are written followed by "buffer_overflow" warning
+// and logger falls back to synchronous writing until next Logger.Flush or Logger.Discard.
+// Logger created with zap loggers is returned unchanged.
+func (l *Logger) Deferred() *Logger {
+	d := &deferredWriter{ws: l.out}
+
+	nl, err := l.withOutput(d, nil)
+	if err != nil {
+		return l
+	}
+
+	nl.deferred = d
+	d.overflow = func() {
+		nl.sugared.Warnw("buffer_overflow", "max_entries", maxDeferredEntries)
+	}
+
+	return nl
+}
+
+// Flush writes entries buffered by deferred logger.
+//
+// Flushing stops if ctx is done, remaining entries are discarded.
+func (l *Logger) Flush(ctx context.Context) error {
+	if l.deferred == nil {
+		return nil
+	}
+
+	err := l.deferred.writeAll(ctx, l.deferred.take())
+
+	return multierr.Append(err, l.deferred.ws.Sync())
+}
+
+// Discard drops entries buffered by deferred logger.
+func (l *Logger) Discard() {
+	if l.deferred != nil {
+		l.deferred.take()
+	}
+}
@@ -0,0 +1,64 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_Deferred(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	ctx := context.Background()
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	d := c.Deferred()
+
+	d.Info(ctx, "committed")
+	c.Info(ctx, "direct")
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"direct"}
+`, w.String())
+
+	require.NoError(t, d.Flush(ctx))
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"direct"}
+{"level":"info","time":"<stripped>","msg":"committed"}
+`, w.String())
+
+	w.Reset()
+	d.Info(ctx, "rolled back")
+	d.Discard()
+	require.NoError(t, d.Flush(ctx))
+	assert.Empty(t, w.String())
+}
+
+func TestLogger_Deferred_overflow(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	ctx := context.Background()
+
+	d := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).Deferred()
+
+	for i := 0; i < 1000; i++ {
+		d.Info(ctx, "buffered")
+	}
+
+	assert.Empty(t, w.String())
+
+	d.Info(ctx, "sync")
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	require.Len(t, lines, 1002)
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"buffer_overflow","max_entries":1000}`, lines[1000])
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"sync"}`, lines[1001])
+}
@@ -0,0 +1,138 @@
+package zapctxd
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const truncatedDepth = "[truncated: max depth]"
+
+// WithMaxDepth returns a logger that limits nesting of object fields to depth levels.
+//
+// Objects nested deeper are replaced with "[truncated: max depth]" string,
+// this protects against cyclic or very deep data structures.
+func (l *Logger) WithMaxDepth(depth int) *Logger {
+	nl := *l
+
+	opt := zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return depthCore{Core: c, depth: depth}
+	})
+
+	nl.options = append(l.options[:len(l.options):len(l.options)], opt)
+	nl.zl = l.zl.WithOptions(opt)
+	nl.zdebug = l.zdebug.WithOptions(opt)
+	nl.sugared = nl.zl.Sugar()
+	nl.debug = nl.zdebug.Sugar()
+
+	return &nl
+}
+
+// depthCore wraps object and array fields to limit nesting depth.
+type depthCore struct {
+	zapcore.Core
+
+	depth int
+}
+
+func (c depthCore) With(fields []zapcore.Field) zapcore.Core {
+	return depthCore{Core: c.Core.With(c.limit(fields)), depth: c.depth}
+}
+
+func (c depthCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapper(c.Core, c, ent, ce)
+}
+
+func (c depthCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.limit(fields))
+}
+
+func (c depthCore) limit(fields []zapcore.Field) []zapcore.Field {
+	var limited []zapcore.Field
+
+	for i, f := range fields {
+		switch f.Type { //nolint:exhaustive // Only nested types are limited.
+		case zapcore.ObjectMarshalerType:
+			if c.depth <= 0 {
+				f = zap.String(f.Key, truncatedDepth)
+			} else {
+				f.Interface = depthObject{m: f.Interface.(zapcore.ObjectMarshaler), depth: c.depth - 1} //nolint:errcheck
+			}
+		case zapcore.ArrayMarshalerType:
+			f.Interface = depthArray{m: f.Interface.(zapcore.ArrayMarshaler), depth: c.depth} //nolint:errcheck
+		default:
+			continue
+		}
+
+		if limited == nil {
+			limited = append(make([]zapcore.Field, 0, len(fields)), fields...)
+		}
+
+		limited[i] = f
+	}
+
+	if limited == nil {
+		return fields
+	}
+
+	return limited
+}
+
+// depthObject marshals object with depth levels of nesting remaining.
+type depthObject struct {
+	m     zapcore.ObjectMarshaler
+	depth int
+}
+
+func (o depthObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return o.m.MarshalLogObject(depthObjectEncoder{ObjectEncoder: enc, depth: o.depth})
+}
+
+// depthArray marshals array with depth levels of nesting remaining.
+type depthArray struct {
+	m     zapcore.ArrayMarshaler
+	depth int
+}
+
+func (a depthArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return a.m.MarshalLogArray(depthArrayEncoder{ArrayEncoder: enc, depth: a.depth})
+}
+
+type depthObjectEncoder struct {
+	zapcore.ObjectEncoder
+
+	depth int
+}
+
+func (e depthObjectEncoder) AddObject(key string, m zapcore.ObjectMarshaler) error {
+	if e.depth <= 0 {
+		e.ObjectEncoder.AddString(key, truncatedDepth)
+
+		return nil
+	}
+
+	return e.ObjectEncoder.AddObject(key, depthObject{m: m, depth: e.depth - 1})
+}
+
+func (e depthObjectEncoder) AddArray(key string, m zapcore.ArrayMarshaler) error {
+	return e.ObjectEncoder.AddArray(key, depthArray{m: m, depth: e.depth})
+}
+
+type depthArrayEncoder struct {
+	zapcore.ArrayEncoder
+
+	depth int
+}
+
+func (e depthArrayEncoder) AppendObject(m zapcore.ObjectMarshaler) error {
+	if e.depth <= 0 {
+		e.ArrayEncoder.AppendString(truncatedDepth)
+
+		return nil
+	}
+
+	return e.ArrayEncoder.AppendObject(depthObject{m: m, depth: e.depth - 1})
+}
+
+func (e depthArrayEncoder) AppendArray(m zapcore.ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(depthArray{m: m, depth: e.depth})
+}
@@ -0,0 +1,61 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+type node struct {
+	name  string
+	child *node
+}
+
+func (n *node) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", n.name)
+
+	if n.child != nil {
+		return enc.AddObject("child", n.child)
+	}
+
+	return nil
+}
+
+func TestLogger_WithMaxDepth(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithMaxDepth(2)
+
+	cyclic := &node{name: "a"}
+	cyclic.child = &node{name: "b", child: cyclic}
+
+	c.Info(context.Background(), "hello", "tree", cyclic)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello",`+
+		`"tree":{"name":"a","child":{"name":"b","child":"[truncated: max depth]"}}}
+`, w.String())
+}
+
+func TestLogger_WithMaxDepth_sampling(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output:   w,
+		Sampling: &zapctxd.SamplingConfig{Initial: 1, Thereafter: 100},
+	}).WithMaxDepth(1)
+
+	for i := 0; i < 100; i++ {
+		c.Info(context.Background(), "hello")
+	}
+
+	assert.Equal(t, 1, strings.Count(w.String(), "\n"))
+}
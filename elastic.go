@@ -0,0 +1,184 @@
+package zapctxd
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	elasticBatchSize     = 500
+	elasticFlushInterval = time.Second
+	elasticQueueSize     = 10000
+)
+
+// ElasticDocument is a log entry to index.
+type ElasticDocument struct {
+	Index string
+	ID    string
+	Body  []byte
+}
+
+// ElasticClient indexes documents with Elasticsearch bulk API.
+//
+// It can be implemented with a client library, e.g. github.com/elastic/go-elasticsearch.
+type ElasticClient interface {
+	Bulk(ctx context.Context, docs []ElasticDocument) error
+}
+
+// WithElastic returns a clone of logger that indexes entries as documents in Elasticsearch.
+//
+// Index can have strftime-style time placeholders for rotation, e.g. "logs-%Y.%m.%d",
+// supported placeholders are %Y, %m, %d, %H and %%. Document ID is a value of "log_id" field
+// (see Logger.WithIDField) or a generated UUID.
+//
+// Documents are sent in batches every second or when batch reaches 500 entries, failures are
+// logged with original logger. When queue is full the entry is dropped and counted in
+// LoggerMetrics.DroppedEntries.
+// Original logger is not affected, Close of the clone sends queued documents.
+func (l *Logger) WithElastic(client ElasticClient, index string) (*Logger, error) {
+	ew := &elasticWriter{
+		client: client,
+		index:  index,
+		idKey:  orDefault(l.fieldNames.LogID, "log_id"),
+		logger: l,
+		batch:  make([]ElasticDocument, 0, elasticBatchSize),
+	}
+
+	aw := NewAsyncWriter(ew, elasticQueueSize)
+	aw.metrics = l.metrics
+
+	stop := make(chan struct{})
+
+	go func() {
+		t := time.NewTicker(elasticFlushInterval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				_ = aw.Sync() //nolint:errcheck // Failures are logged by writer.
+			}
+		}
+	}()
+
+	return l.withOutput(aw, func() error {
+		close(stop)
+
+		return aw.Close()
+	})
+}
+
+// elasticWriter collects written entries in batches of documents, it is used by a single goroutine of AsyncWriter.
+type elasticWriter struct {
+	client ElasticClient
+	index  string
+	idKey  string
+	logger *Logger
+	batch  []ElasticDocument
+}
+
+func (w *elasticWriter) Write(p []byte) (int, error) {
+	w.batch = append(w.batch, ElasticDocument{
+		Index: elasticIndex(w.index, time.Now()),
+		ID:    w.documentID(p),
+		Body:  p,
+	})
+
+	if len(w.batch) >= elasticBatchSize {
+		w.flush()
+	}
+
+	return len(p), nil
+}
+
+func (w *elasticWriter) flush() {
+	if len(w.batch) == 0 {
+		return
+	}
+
+	if err := w.client.Bulk(context.Background(), w.batch); err != nil {
+		w.logger.sugared.Warnw("failed to index log entries", "error", err.Error(), "count", len(w.batch))
+	}
+
+	w.batch = w.batch[:0]
+}
+
+func (w *elasticWriter) documentID(p []byte) string {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(p, &fields); err == nil {
+		var id string
+
+		if err := json.Unmarshal(fields[w.idKey], &id); err == nil && id != "" {
+			return id
+		}
+	}
+
+	return newUUID()
+}
+
+// Sync indexes collected documents.
+func (w *elasticWriter) Sync() error {
+	w.flush()
+
+	return nil
+}
+
+// Close indexes collected documents.
+func (w *elasticWriter) Close() error {
+	w.flush()
+
+	return nil
+}
+
+// elasticIndex replaces strftime-style placeholders in index pattern with values of t.
+func elasticIndex(pattern string, t time.Time) string {
+	if !strings.Contains(pattern, "%") {
+		return pattern
+	}
+
+	t = t.UTC()
+
+	var b strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' || i == len(pattern)-1 {
+			b.WriteByte(pattern[i])
+
+			continue
+		}
+
+		i++
+
+		switch pattern[i] {
+		case 'Y':
+			b.WriteString(strconv.Itoa(t.Year()))
+		case 'm':
+			b.WriteString(twoDigits(int(t.Month())))
+		case 'd':
+			b.WriteString(twoDigits(t.Day()))
+		case 'H':
+			b.WriteString(twoDigits(t.Hour()))
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(pattern[i])
+		}
+	}
+
+	return b.String()
+}
+
+func twoDigits(v int) string {
+	if v < 10 {
+		return "0" + strconv.Itoa(v)
+	}
+
+	return strconv.Itoa(v)
+}
@@ -0,0 +1,89 @@
+package zapctxd_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+type elasticClient struct {
+	mu      sync.Mutex
+	batches [][]zapctxd.ElasticDocument
+	err     error
+}
+
+func (c *elasticClient) Bulk(_ context.Context, docs []zapctxd.ElasticDocument) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.batches = append(c.batches, append([]zapctxd.ElasticDocument(nil), docs...))
+
+	return c.err
+}
+
+func TestLogger_WithElastic(t *testing.T) {
+	client := &elasticClient{}
+
+	c, err := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+	}).WithElastic(client, "logs-%Y.%m-100%%")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	c.Info(ctx, "hello", "foo", 1)
+	c.WithIDField().Info(ctx, "with id")
+
+	require.NoError(t, c.Sync())
+	require.Len(t, client.batches, 1)
+
+	docs := client.batches[0]
+	require.Len(t, docs, 2)
+
+	index := "logs-" + time.Now().UTC().Format("2006.01") + "-100%"
+	assert.Equal(t, index, docs[0].Index)
+	assert.Equal(t, index, docs[1].Index)
+
+	assert.Len(t, docs[0].ID, 36)
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","foo":1}`+"\n", string(docs[0].Body))
+
+	var body struct {
+		LogID string `json:"log_id"`
+	}
+
+	require.NoError(t, json.Unmarshal(docs[1].Body, &body))
+	assert.Equal(t, body.LogID, docs[1].ID)
+
+	c.Info(ctx, "on close")
+	require.NoError(t, c.Close())
+	require.Len(t, client.batches, 2)
+	assert.Len(t, client.batches[1], 1)
+}
+
+func TestLogger_WithElastic_failed(t *testing.T) {
+	client := &elasticClient{err: errors.New("unavailable")}
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	ec, err := c.WithElastic(client, "logs")
+	require.NoError(t, err)
+
+	ec.Info(context.Background(), "hello")
+	require.NoError(t, ec.Close())
+
+	assert.Equal(t, "logs", client.batches[0][0].Index)
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"failed to index log entries","error":"unavailable","count":1}
+`, w.String())
+}
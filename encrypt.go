@@ -0,0 +1,70 @@
+package zapctxd
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// FieldEncrypter encrypts values of sensitive fields, it can be backed by HSM or KMS.
+type FieldEncrypter interface {
+	// Encrypts tells if values of a field should be encrypted.
+	Encrypts(key string) bool
+
+	// Encrypt returns version of encryption key and ciphertext of value.
+	Encrypt(key string, plaintext []byte) (keyVersion string, ciphertext []byte, err error)
+}
+
+// AEADEncrypter is a FieldEncrypter with AEAD ciphers by field key.
+//
+// Ciphertext contains nonce followed by sealed value, field key is used as additional data.
+type AEADEncrypter struct {
+	// KeyVersion identifies ciphers, default "1".
+	KeyVersion string
+	Ciphers    map[string]cipher.AEAD
+}
+
+// Encrypts implements FieldEncrypter.
+func (e AEADEncrypter) Encrypts(key string) bool {
+	_, ok := e.Ciphers[key]
+
+	return ok
+}
+
+// Encrypt implements FieldEncrypter.
+func (e AEADEncrypter) Encrypt(key string, plaintext []byte) (string, []byte, error) {
+	c, ok := e.Ciphers[key]
+	if !ok {
+		return "", nil, fmt.Errorf("no cipher for %s", key)
+	}
+
+	nonce := make([]byte, c.NonceSize(), c.NonceSize()+len(plaintext)+c.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+
+	keyVersion := e.KeyVersion
+	if keyVersion == "" {
+		keyVersion = "1"
+	}
+
+	return keyVersion, c.Seal(nonce, nonce, plaintext, []byte(key)), nil
+}
+
+// encryptValue replaces string values of sensitive fields with "enc:<key version>:<base64 ciphertext>".
+func encryptValue(enc FieldEncrypter) func(key string, value any) (any, bool) {
+	return func(key string, value any) (any, bool) {
+		v, ok := value.(string)
+		if !ok || !enc.Encrypts(key) {
+			return value, false
+		}
+
+		ver, ct, err := enc.Encrypt(key, []byte(v))
+		if err != nil {
+			return "[encryption failed]", true
+		}
+
+		return "enc:" + ver + ":" + base64.StdEncoding.EncodeToString(ct), true
+	}
+}
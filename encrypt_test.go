@@ -0,0 +1,80 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestConfig_EncryptedFields(t *testing.T) {
+	block, err := aes.NewCipher(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:       true,
+		Output:          w,
+		EncryptedFields: map[string]cipher.AEAD{"ssn": aead},
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "ssn", "123-45-6789")
+
+	c.Info(ctx, "hello", "foo", "bar")
+
+	var e map[string]any
+
+	require.NoError(t, json.Unmarshal(w.Bytes(), &e))
+	assert.Equal(t, "bar", e["foo"])
+
+	parts := strings.SplitN(e["ssn"].(string), ":", 3)
+	require.Len(t, parts, 3)
+	assert.Equal(t, "enc", parts[0])
+	assert.Equal(t, "1", parts[1])
+
+	ct, err := base64.StdEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+
+	pt, err := aead.Open(nil, ct[:aead.NonceSize()], ct[aead.NonceSize():], []byte("ssn"))
+	require.NoError(t, err)
+	assert.Equal(t, "123-45-6789", string(pt))
+}
+
+type encrypterMock struct{}
+
+func (encrypterMock) Encrypts(key string) bool {
+	return key == "token"
+}
+
+func (encrypterMock) Encrypt(_ string, plaintext []byte) (string, []byte, error) {
+	return "hsm-7", []byte(strings.ToUpper(string(plaintext))), nil
+}
+
+func TestConfig_FieldEncrypter(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:      true,
+		Output:         w,
+		FieldEncrypter: encrypterMock{},
+	})
+
+	c.Info(context.Background(), "hello", "token", "abc", "other", "abc")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","token":"enc:hsm-7:QUJD","other":"abc"}
+`, w.String())
+}
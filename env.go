@@ -0,0 +1,135 @@
+package zapctxd
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// EnvironmentBehavior defines logger adjustments for environment tier in Logger.WithEnvironment.
+type EnvironmentBehavior struct {
+	// DevMode enables development mode if it is not enabled yet.
+	DevMode bool
+	// DisableWarnStacktrace limits stack traces of Config.ErrorStackTrace to Error level and above,
+	// it does not enable stack traces.
+	DisableWarnStacktrace bool
+}
+
+// DefaultEnvironmentBehaviors are used by Logger.WithEnvironment unless Config.EnvironmentBehaviors is set.
+var DefaultEnvironmentBehaviors = map[string]EnvironmentBehavior{
+	"development": {DevMode: true},
+	"production":  {DisableWarnStacktrace: true},
+}
+
+// WithEnvironment returns a logger that adds environment tier to every entry.
+//
+// Behavior of logger is adjusted for environment with Config.EnvironmentBehaviors or DefaultEnvironmentBehaviors,
+// by default "development" enables development mode and "production" disables stack traces at Warn level.
+// Field name can be configured with Config.FieldNames.Environment, default "env".
+func (l *Logger) WithEnvironment(env string) *Logger {
+	key := orDefault(l.fieldNames.Environment, "env")
+
+	behaviors := l.cfg.EnvironmentBehaviors
+	if behaviors == nil {
+		behaviors = DefaultEnvironmentBehaviors
+	}
+
+	b := behaviors[env]
+	raiseStack := b.DisableWarnStacktrace && l.stacktrace && l.stackLevel < zapcore.ErrorLevel
+
+	if l.mu == nil || (!raiseStack && (!b.DevMode || l.devMode)) {
+		return l.with(zap.String(key, env))
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	nl := *l
+
+	nl.mu = &sync.RWMutex{}
+	nl.options = l.options[:len(l.options):len(l.options)]
+
+	if b.DevMode && !l.devMode {
+		nl.devMode = true
+		nl.cfg.DevMode = true
+		nl.options = append(nl.options, zap.Development())
+
+		if !l.caller {
+			nl.caller = true
+			nl.options = append(nl.options, zap.AddCaller())
+		}
+
+		if !l.callerSkip {
+			nl.callerSkip = true
+			nl.options = append(nl.options, zap.AddCallerSkip(1))
+		}
+
+		format := l.cfg.Encoding
+		if format == "" {
+			format = encodingConsole
+		}
+
+		nl.format = format
+		nl.encoder = newEncoder(nl.cfg, format, l.encoderOptions...)
+	}
+
+	if raiseStack {
+		nl.stackLevel = zapcore.ErrorLevel
+		nl.options = append(nl.options, zap.AddStacktrace(zapcore.ErrorLevel))
+	}
+
+	nl.make()
+
+	return nl.with(zap.String(key, env))
+}
+
+// stacktraceLevel returns minimal level of stack traces enabled by zap options, e.g. zap.AddStacktrace.
+func stacktraceLevel(options []zap.Option) (zapcore.Level, bool) {
+	z := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), zapcore.AddSync(io.Discard),
+		zapcore.DebugLevel), options...)
+
+	for level := zapcore.DebugLevel; level <= zapcore.ErrorLevel; level++ {
+		if ce := z.Check(level, ""); ce != nil && ce.Stack != "" {
+			return level, true
+		}
+	}
+
+	return 0, false
+}
+
+// serviceFields returns service and process metadata fields of configuration.
+func serviceFields(cfg Config) []zap.Field {
+	names := [...]struct{ key, def, value string }{
+		{cfg.FieldNames.Service, "service", cfg.ServiceName},
+		{cfg.FieldNames.Version, "version", cfg.ServiceVersion},
+		{cfg.FieldNames.Environment, "env", cfg.Environment},
+	}
+
+	var fields []zap.Field
+
+	for _, n := range names {
+		if n.value == "" {
+			continue
+		}
+
+		fields = append(fields, zap.String(orDefault(n.key, n.def), n.value))
+	}
+
+	if cfg.IncludeHostname {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = err.Error()
+		}
+
+		fields = append(fields, zap.String(orDefault(cfg.FieldNames.Hostname, "hostname"), hostname))
+	}
+
+	if cfg.IncludePID {
+		fields = append(fields, zap.Int(orDefault(cfg.FieldNames.PID, "pid"), os.Getpid()))
+	}
+
+	return fields
+}
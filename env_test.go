@@ -0,0 +1,153 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithEnvironment(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}, zap.AddStacktrace(zap.WarnLevel))
+
+	c.WithEnvironment("production").Warn(context.Background(), "hello")
+	c.WithEnvironment("staging").Info(context.Background(), "hi")
+
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"hello","env":"production"}
+{"level":"info","time":"<stripped>","msg":"hi","env":"staging"}
+`, w.String())
+
+	w.Reset()
+	c.Warn(context.Background(), "with stack")
+	assert.Contains(t, w.String(), `"stacktrace":`)
+}
+
+func TestLogger_WithEnvironment_development(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+		FieldNames: zapctxd.FieldNames{
+			Environment: "tier",
+		},
+	})
+
+	c.WithEnvironment("development").Info(context.Background(), "hello")
+
+	out := w.String()
+	assert.True(t, strings.HasPrefix(out, "<stripped>\tINFO\tzapctxd/env_test.go:"), out)
+	assert.Contains(t, out, `{"tier": "development"}`)
+}
+
+func TestConfig_ServiceName(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:      true,
+		Output:         w,
+		ServiceName:    "billing",
+		ServiceVersion: "v1.2.3",
+		Environment:    "staging",
+		FieldNames:     zapctxd.FieldNames{Service: "svc"},
+	})
+
+	c.Info(context.Background(), "hello", "foo", "bar")
+	c.With("app", 1).Info(context.Background(), "with")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","svc":"billing","version":"v1.2.3","env":"staging","foo":"bar"}
+{"level":"info","time":"<stripped>","msg":"with","svc":"billing","version":"v1.2.3","env":"staging","app":1}
+`, w.String())
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime:   true,
+		Output:      w,
+		DevMode:     true,
+		ServiceName: "billing",
+	})
+
+	c.Info(context.Background(), "hello")
+
+	assert.Contains(t, w.String(), `hello	{"service": "billing"}`)
+}
+
+func TestConfig_IncludeHostname(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = err.Error()
+	}
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:       true,
+		Output:          w,
+		IncludeHostname: true,
+		IncludePID:      true,
+		FieldNames:      zapctxd.FieldNames{PID: "process_id"},
+	})
+
+	c.Info(context.Background(), "hello")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","hostname":"`+hostname+
+		`","process_id":`+strconv.Itoa(os.Getpid())+"}\n", w.String())
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime:  true,
+		Output:     w,
+		IncludePID: true,
+	})
+
+	c.Info(context.Background(), "hello")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","pid":`+strconv.Itoa(os.Getpid())+"}\n", w.String())
+}
+
+func TestLogger_WithEnvironment_noStacktrace(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithEnvironment("production")
+
+	c.Error(context.Background(), "failed")
+	c.Warn(context.Background(), "warning")
+
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"failed","env":"production"}
+{"level":"warn","time":"<stripped>","msg":"warning","env":"production"}
+`, w.String())
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime:          true,
+		Output:             w,
+		ErrorStackTrace:    true,
+		StackTraceMinLevel: zap.WarnLevel,
+	}).WithEnvironment("production")
+
+	c.Warn(context.Background(), "warning")
+	c.Error(context.Background(), "failed")
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.NotContains(t, lines[0], "stacktrace")
+	assert.Contains(t, lines[1], `"stacktrace":`)
+}
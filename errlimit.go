@@ -0,0 +1,114 @@
+package zapctxd
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	suppressedErrorsSummaryInterval = 24 * time.Hour
+	errorLimiterCacheSize           = 1000
+)
+
+// WithRateLimitedErrors returns a clone of logger that writes at most maxPerMin error entries
+// with the same message per minute.
+//
+// Suppressed entries are counted and reported daily with "suppressed repeated errors" entry
+// per message with "suppressed_error_count" field. Summary is written with the next error entry
+// of the message after a day, counts of up to 1000 recent messages are kept, counts of older
+// messages are reported when they are evicted.
+// Original logger is not affected, Close of the clone reports pending counts.
+func (l *Logger) WithRateLimitedErrors(maxPerMin int) *Logger {
+	el := &errorLimiter{
+		max:      maxPerMin,
+		interval: suppressedErrorsSummaryInterval,
+		counts:   newLRU[*errorCount](errorLimiterCacheSize),
+		logger:   l,
+	}
+
+	el.counts.evicted = func(msg string, c *errorCount) {
+		el.report(msg, c.suppressed)
+	}
+
+	nl := l.withFilter(func(e entry) bool {
+		if e.level != zap.ErrorLevel {
+			return false
+		}
+
+		return !el.allow(e.msg, time.Now())
+	})
+
+	nl.closers = append(l.closers[:len(l.closers):len(l.closers)], el.Close)
+
+	return nl
+}
+
+type errorCount struct {
+	since      time.Time
+	window     time.Time
+	count      int
+	suppressed int64
+}
+
+type errorLimiter struct {
+	max      int
+	interval time.Duration
+	logger   *Logger
+	counts   *lru[*errorCount]
+}
+
+func (el *errorLimiter) allow(msg string, now time.Time) bool {
+	var (
+		allowed    bool
+		suppressed int64
+	)
+
+	el.counts.update(msg, func(c *errorCount, found bool) *errorCount {
+		if !found {
+			c = &errorCount{since: now}
+		}
+
+		if now.Sub(c.since) >= el.interval {
+			suppressed = c.suppressed
+			c.since = now
+			c.suppressed = 0
+		}
+
+		if now.Sub(c.window) >= time.Minute {
+			c.window = now
+			c.count = 0
+		}
+
+		if c.count >= el.max {
+			c.suppressed++
+
+			return c
+		}
+
+		c.count++
+		allowed = true
+
+		return c
+	})
+
+	el.report(msg, suppressed)
+
+	return allowed
+}
+
+// report writes summary of suppressed entries of message.
+func (el *errorLimiter) report(msg string, suppressed int64) {
+	if suppressed > 0 {
+		el.logger.sugared.Errorw("suppressed repeated errors", "entry_msg", msg, "suppressed_error_count", suppressed)
+	}
+}
+
+// Close reports suppressed entries and resets counters.
+func (el *errorLimiter) Close() error {
+	for msg, c := range el.counts.reset() {
+		el.report(msg, c.suppressed)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithRateLimitedErrors(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithRateLimitedErrors(2)
+
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		c.Error(ctx, "timeout")
+		c.Warn(ctx, "not limited")
+	}
+
+	c.Error(ctx, "other")
+
+	assert.Equal(t, 2, strings.Count(w.String(), `"msg":"timeout"`))
+	assert.Equal(t, 10, strings.Count(w.String(), `"msg":"not limited"`))
+	assert.Equal(t, 1, strings.Count(w.String(), `"msg":"other"`))
+
+	w.Reset()
+
+	require.NoError(t, c.Close())
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"suppressed repeated errors","entry_msg":"timeout","suppressed_error_count":8}
+`, w.String())
+}
+
+func TestLogger_WithRateLimitedErrors_evicted(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithRateLimitedErrors(1)
+
+	ctx := context.Background()
+
+	c.Error(ctx, "timeout")
+	c.Error(ctx, "timeout")
+
+	for i := 0; i < 1000; i++ {
+		c.Error(ctx, "failed", "i", i)
+		c.Error(ctx, strconv.Itoa(i))
+	}
+
+	assert.Contains(t, w.String(), `{"level":"error","time":"<stripped>","msg":"suppressed repeated errors","entry_msg":"timeout","suppressed_error_count":1}
+`)
+
+	w.Reset()
+
+	require.NoError(t, c.Close())
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"suppressed repeated errors","entry_msg":"failed","suppressed_error_count":999}
+`, w.String())
+}
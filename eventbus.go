@@ -0,0 +1,33 @@
+package zapctxd
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventBus publishes events to topics.
+type EventBus interface {
+	Publish(topic string, event any) error
+}
+
+// WithEventBus returns a logger that publishes written entries to bus.
+//
+// Entry is published to "logs.<level>" topic as map[string]any with "level", "msg", "time"
+// and merged fields.
+func (l *Logger) WithEventBus(bus EventBus) *Logger {
+	return l.withHook(func(e entry) {
+		event := make(map[string]any, 3+len(e.kv)/2)
+
+		for i := 0; i < len(e.kv)-1; i += 2 {
+			event[fmt.Sprint(e.kv[i])] = e.kv[i+1]
+		}
+
+		event["level"] = e.level.String()
+		event["msg"] = e.msg
+		event["time"] = time.Now()
+
+		if err := bus.Publish("logs."+e.level.String(), event); err != nil {
+			l.sugared.Warnw("failed to publish log entry", "error", err.Error(), "entry_msg", e.msg)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+type eventBus struct {
+	topics []string
+	events []map[string]any
+	err    error
+}
+
+func (b *eventBus) Publish(topic string, event any) error {
+	b.topics = append(b.topics, topic)
+	b.events = append(b.events, event.(map[string]any))
+
+	return b.err
+}
+
+func TestLogger_WithEventBus(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	bus := &eventBus{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithEventBus(bus)
+
+	c.Warn(ctxd.AddFields(context.Background(), "user", "john"), "suspicious login", "attempts", 3)
+
+	assert.Equal(t, []string{"logs.warn"}, bus.topics)
+	require.Len(t, bus.events, 1)
+	assert.Equal(t, "warn", bus.events[0]["level"])
+	assert.Equal(t, "suspicious login", bus.events[0]["msg"])
+	assert.Equal(t, 3, bus.events[0]["attempts"])
+	assert.Equal(t, "john", bus.events[0]["user"])
+	assert.Contains(t, bus.events[0], "time")
+
+	bus.err = errors.New("unavailable")
+	w.Reset()
+
+	c.Info(context.Background(), "hello")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello"}
+{"level":"warn","time":"<stripped>","msg":"failed to publish log entry","error":"unavailable","entry_msg":"hello"}
+`, w.String())
+}
@@ -0,0 +1,66 @@
+package zapctxd
+
+import (
+	"os"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithStdoutFallback returns a clone of logger that writes entry to os.Stdout with "fallback": true field
+// if write to output fails.
+//
+// It is a last-resort safety net for outputs like network or files. Logger created with zap loggers
+// is returned unchanged.
+func (l *Logger) WithStdoutFallback() *Logger {
+	if l.mu == nil {
+		return l
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	nl := *l
+	stdout := zapcore.Lock(os.Stdout)
+	enc := l.encoder
+
+	nl.mu = &sync.RWMutex{}
+	nl.options = append(l.options[:len(l.options):len(l.options)], zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return fallbackCore{Core: c, fallback: zapcore.NewCore(enc, stdout, c)}
+	}))
+	nl.make()
+
+	return &nl
+}
+
+// fallbackCore writes entries to fallback core if primary core fails.
+type fallbackCore struct {
+	zapcore.Core
+
+	fallback zapcore.Core
+}
+
+func (c fallbackCore) With(fields []zapcore.Field) zapcore.Core {
+	return fallbackCore{Core: c.Core.With(fields), fallback: c.fallback.With(fields)}
+}
+
+func (c fallbackCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapper(c.Core, c, ent, ce)
+}
+
+func (c fallbackCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	err := c.Core.Write(ent, fields)
+	if err == nil {
+		return nil
+	}
+
+	fields = append(fields[:len(fields):len(fields)], zap.Bool("fallback", true))
+
+	if ferr := c.fallback.Write(ent, fields); ferr != nil {
+		return multierr.Append(err, ferr)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("failed")
+}
+
+func TestLogger_WithStdoutFallback(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	defer func() { os.Stdout = stdout }()
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    failingWriter{},
+	}).WithNodeID("n1").WithStdoutFallback()
+
+	os.Stdout = stdout
+
+	c.Error(ctxd.AddFields(context.Background(), "foo", "bar"), "failed to write")
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"failed to write","node_id":"n1","foo":"bar","fallback":true}
+`, string(out))
+}
+
+func TestLogger_WithStdoutFallback_sampling(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output:   w,
+		Sampling: &zapctxd.SamplingConfig{Initial: 1, Thereafter: 100},
+	}).WithStdoutFallback()
+
+	for i := 0; i < 100; i++ {
+		c.Info(context.Background(), "hello")
+	}
+
+	assert.Equal(t, 1, strings.Count(w.String(), "\n"))
+}
@@ -0,0 +1,111 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_Fatal(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:  true,
+		ZapOptions: []zap.Option{zap.WithFatalHook(zapcore.WriteThenPanic)},
+	})
+
+	ctx := ctxd.WithLogWriter(ctxd.AddFields(context.Background(), "user", "john"), w)
+	err := ctxd.NewError(ctx, "failed", "code", 42)
+
+	assert.Panics(t, func() {
+		c.Fatal(ctx, "fatal", "error", err)
+	})
+
+	assert.Equal(t, `{"level":"fatal","time":"<stripped>","msg":"fatal","error":"failed","user":"john","code":42}
+`, w.String())
+}
+
+func TestLogger_Panic(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	ctx := context.Background()
+
+	var fired string
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	c.OnFirstError(func(ctx context.Context, msg string, keysAndValues []any) {
+		fired = msg
+	})
+
+	assert.PanicsWithValue(t, "panic", func() {
+		c.Panic(ctx, "panic", "error", errors.New("failed"))
+	})
+
+	assert.Equal(t, `{"level":"panic","time":"<stripped>","msg":"panic","error":"failed"}
+`, w.String())
+	assert.Equal(t, "panic", fired)
+	assert.Equal(t, int64(1), c.Metrics().Entries["panic"])
+}
+
+func TestLogger_Fatal_filtered(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:  true,
+		Output:     w,
+		ZapOptions: []zap.Option{zap.WithFatalHook(zapcore.WriteThenPanic)},
+	}).WithMaxRate(1, time.Minute)
+
+	assert.Panics(t, func() {
+		c.Fatal(context.Background(), "fatal")
+	})
+
+	assert.PanicsWithValue(t, "fatal", func() {
+		c.Fatal(context.Background(), "fatal")
+	})
+
+	assert.Equal(t, 1, strings.Count(w.String(), `"msg":"fatal"`))
+
+	disabled := zapctxd.New(zapctxd.Config{
+		Output:     w,
+		ZapOptions: []zap.Option{zap.WithFatalHook(zapcore.WriteThenPanic)},
+	})
+
+	assert.Panics(t, func() {
+		disabled.Fatal(zapctxd.WithLevel(context.Background(), zap.FatalLevel+1), "disabled")
+	})
+
+	assert.NotContains(t, w.String(), "disabled")
+}
+
+func TestLogger_Panic_filtered(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithIdempotencyKey(func(context.Context) string { return "key" })
+
+	assert.PanicsWithValue(t, "panic", func() {
+		c.Panic(context.Background(), "panic")
+	})
+
+	assert.PanicsWithValue(t, "panic", func() {
+		c.Panic(context.Background(), "panic")
+	})
+
+	assert.Equal(t, 1, strings.Count(w.String(), `"msg":"panic"`))
+}
@@ -0,0 +1,75 @@
+package zapctxd
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/bool64/ctxd"
+)
+
+// WithFieldCache returns a logger that caches fields of context by context identity for ttl.
+//
+// It is useful when the same context is used for many log entries, for example in stream processing.
+// Expired entries are evicted lazily on access, so that no background goroutine is needed.
+func (l *Logger) WithFieldCache(ttl time.Duration) *Logger {
+	nl := *l
+
+	nl.fieldCache = nil
+
+	if ttl > 0 {
+		nl.fieldCache = &fieldCache{ttl: ttl, entries: make(map[context.Context]cachedFields)}
+	}
+
+	return &nl
+}
+
+type cachedFields struct {
+	fv      []any
+	expires time.Time
+}
+
+type fieldCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	swept   time.Time
+	entries map[context.Context]cachedFields
+}
+
+// fields returns cached or freshly extracted fields of context.
+func (c *fieldCache) fields(ctx context.Context) []any {
+	// Contexts of non-comparable types can not be used as map keys.
+	if !reflect.TypeOf(ctx).Comparable() {
+		return ctxd.Fields(ctx)
+	}
+
+	now := time.Now()
+
+	c.mu.Lock()
+	cf, ok := c.entries[ctx]
+	c.mu.Unlock()
+
+	if ok && now.Before(cf.expires) {
+		return cf.fv
+	}
+
+	fv := ctxd.Fields(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[ctx] = cachedFields{fv: fv, expires: now.Add(c.ttl)}
+
+	if now.Sub(c.swept) >= c.ttl {
+		c.swept = now
+
+		for k, v := range c.entries {
+			if !now.Before(v.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	return fv
+}
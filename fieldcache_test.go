@@ -0,0 +1,70 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+type countingContext struct {
+	context.Context
+	calls *int
+}
+
+func (c countingContext) Value(key any) any {
+	*c.calls++
+
+	return c.Context.Value(key)
+}
+
+func TestLogger_WithFieldCache(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	uncached := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+	c := uncached.WithFieldCache(50 * time.Millisecond)
+
+	uncachedCalls := 0
+	ctx := countingContext{Context: ctxd.AddFields(context.Background(), "foo", 1), calls: &uncachedCalls}
+
+	for i := 0; i < 3; i++ {
+		uncached.Info(ctx, "hello")
+	}
+
+	w.Reset()
+
+	calls := 0
+	ctx = countingContext{Context: ctx.Context, calls: &calls}
+
+	for i := 0; i < 3; i++ {
+		c.Info(ctx, "hello")
+	}
+
+	// Fields are extracted from context only once.
+	assert.Equal(t, uncachedCalls-2, calls)
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","foo":1}
+{"level":"info","time":"<stripped>","msg":"hello","foo":1}
+{"level":"info","time":"<stripped>","msg":"hello","foo":1}
+`, w.String())
+
+	time.Sleep(60 * time.Millisecond)
+
+	calls = 0
+
+	c.Info(ctx, "hello")
+	assert.Equal(t, uncachedCalls/3, calls)
+
+	// Different context is not affected by cache.
+	w.Reset()
+	c.Info(ctxd.AddFields(context.Background(), "bar", 2), "hello")
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","bar":2}
+`, w.String())
+}
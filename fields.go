@@ -0,0 +1,195 @@
+package zapctxd
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DebugZ logs a message at debug level with strongly typed fields.
+func (l *Logger) DebugZ(ctx context.Context, msg string, fields ...zap.Field) {
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	z := l.getZ(ctx, zap.DebugLevel)
+	if z == nil {
+		return
+	}
+
+	if l.pipelined() {
+		e := entry{ctx: ctx, level: zap.DebugLevel, msg: msg}
+		kv := l.prepareKV(e, fieldsKV(fields))
+
+		if l.skip(e, kv) {
+			return
+		}
+
+		z.Sugar().Debugw(msg, kv...)
+		l.written(e, kv)
+
+		return
+	}
+
+	z.Debug(msg, l.zapFields(ctx, fields)...)
+	l.metrics.write(zap.DebugLevel)
+}
+
+// InfoZ logs a message at info level with strongly typed fields.
+func (l *Logger) InfoZ(ctx context.Context, msg string, fields ...zap.Field) {
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	z := l.getZ(ctx, zap.InfoLevel)
+	if z == nil {
+		return
+	}
+
+	if l.pipelined() {
+		e := entry{ctx: ctx, level: zap.InfoLevel, msg: msg}
+		kv := l.prepareKV(e, fieldsKV(fields))
+
+		if l.skip(e, kv) {
+			return
+		}
+
+		z.Sugar().Infow(msg, kv...)
+		l.written(e, kv)
+
+		return
+	}
+
+	z.Info(msg, l.zapFields(ctx, fields)...)
+	l.metrics.write(zap.InfoLevel)
+}
+
+// WarnZ logs a message at warn level with strongly typed fields.
+func (l *Logger) WarnZ(ctx context.Context, msg string, fields ...zap.Field) {
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	z := l.getZ(ctx, zap.WarnLevel)
+	if z == nil {
+		return
+	}
+
+	if l.pipelined() {
+		e := entry{ctx: ctx, level: zap.WarnLevel, msg: msg}
+		kv := l.prepareKV(e, fieldsKV(fields))
+
+		if l.skip(e, kv) {
+			return
+		}
+
+		z.Sugar().Warnw(msg, kv...)
+		l.written(e, kv)
+
+		return
+	}
+
+	z.Warn(msg, l.zapFields(ctx, fields)...)
+	l.metrics.write(zap.WarnLevel)
+}
+
+// ErrorZ logs a message at error level with strongly typed fields.
+func (l *Logger) ErrorZ(ctx context.Context, msg string, fields ...zap.Field) {
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	z := l.getZ(ctx, zap.ErrorLevel)
+	if z == nil {
+		return
+	}
+
+	if l.pipelined() {
+		e := entry{ctx: ctx, level: zap.ErrorLevel, msg: msg}
+		kv := l.prepareKV(e, fieldsKV(fields))
+
+		if l.skip(e, kv) {
+			return
+		}
+
+		z.Sugar().Errorw(msg, kv...)
+		l.written(e, kv)
+
+		return
+	}
+
+	z.Error(msg, l.zapFields(ctx, fields)...)
+	l.metrics.write(zap.ErrorLevel)
+}
+
+func (l *Logger) getZ(ctx context.Context, level zapcore.Level) *zap.Logger {
+	if l.mu != nil {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	isDebug, ok := l.enabled(ctx, level)
+	if !ok {
+		return nil
+	}
+
+	if z := l.writerLogger(ctx, isDebug); z != nil {
+		return z
+	}
+
+	if isDebug {
+		return l.zdebug
+	}
+
+	return l.zl
+}
+
+// pipelined checks if entries need to be processed as key-value pairs.
+func (l *Logger) pipelined() bool {
+	return l.seq != nil || l.firstError != nil || len(l.scoped) > 0 || l.cfg.DeduplicateFields ||
+		len(l.processors) > 0 || len(l.filters) > 0 || len(l.hooks) > 0
+}
+
+// zapFields appends fields of context to fields.
+func (l *Logger) zapFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	fv, timedOut := l.contextFields(ctx)
+	if len(fv) == 0 && !timedOut {
+		return fields
+	}
+
+	all := make([]zap.Field, 0, len(fields)+len(fv)/2+1)
+	all = append(all, fields...)
+
+	if timedOut {
+		all = append(all, zap.Bool("ctx_timeout", true))
+	}
+
+	for i := 0; i < len(fv)-1; i += 2 {
+		k, ok := fv[i].(string)
+		if !ok {
+			k = fmt.Sprint(fv[i])
+		}
+
+		all = append(all, zap.Any(k, fv[i+1]))
+	}
+
+	return all
+}
+
+// fieldsKV converts fields to key-value pairs.
+func fieldsKV(fields []zap.Field) []any {
+	kv := make([]any, 0, 2*len(fields))
+
+	for _, f := range fields {
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+
+		if v, ok := enc.Fields[f.Key]; ok {
+			kv = append(kv, f.Key, v)
+		}
+	}
+
+	return kv
+}
@@ -0,0 +1,42 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_InfoZ(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "user", "john")
+
+	c.DebugZ(ctx, "skipped", zap.Int("n", 1))
+	c.DebugZ(ctxd.WithDebug(ctx), "debug", zap.Int("n", 1))
+	c.InfoZ(ctx, "info", zap.Int("n", 2), zap.Bool("ok", true))
+	c.WarnZ(context.Background(), "warn")
+	c.ErrorZ(ctx, "error", zap.Error(errors.New("failed")))
+
+	// Pipeline of key-value pairs is used when needed.
+	c.WithOrderedFields("user").InfoZ(ctx, "ordered", zap.String("foo", "bar"))
+
+	assert.Equal(t, `{"level":"debug","time":"<stripped>","msg":"debug","n":1,"user":"john"}
+{"level":"info","time":"<stripped>","msg":"info","n":2,"ok":true,"user":"john"}
+{"level":"warn","time":"<stripped>","msg":"warn"}
+{"level":"error","time":"<stripped>","msg":"error","error":"failed","user":"john"}
+{"level":"info","time":"<stripped>","msg":"ordered","user":"john","foo":"bar"}
+`, w.String())
+	assert.Equal(t, int64(2), c.Metrics().Entries["info"])
+}
@@ -0,0 +1,31 @@
+// Package filelog provides file outputs for zapctxd.Logger.
+package filelog
+
+import (
+	"io"
+	"os"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Rotating returns a writer to a file with size-based rotation, it can be used with zapctxd.Logger.WithOutput.
+//
+// Up to maxBackups of rotated files are retained for maxAgeDays, zero values keep all files.
+// File is checked to be writable before returning, Close of writer closes the file.
+func Rotating(path string, maxSizeMB, maxBackups, maxAgeDays int) (io.WriteCloser, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) //nolint:gosec // Path is configured by user.
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    maxSizeMB,
+		MaxBackups: maxBackups,
+		MaxAge:     maxAgeDays,
+	}, nil
+}
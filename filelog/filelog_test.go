@@ -0,0 +1,46 @@
+package filelog_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/filelog"
+)
+
+func TestRotating(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	f, err := filelog.Rotating(path, 10, 3, 7)
+	require.NoError(t, err)
+
+	fc, err := c.WithOutput(f)
+	require.NoError(t, err)
+
+	fc.Info(context.Background(), "to file")
+	c.Info(context.Background(), "to original")
+	require.NoError(t, fc.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"to file"}
+`, string(b))
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"to original"}
+`, w.String())
+
+	_, err = filelog.Rotating(filepath.Join(t.TempDir(), "missing", "app.log"), 10, 3, 7)
+	assert.Error(t, err)
+}
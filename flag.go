@@ -0,0 +1,55 @@
+package zapctxd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FlagEvaluator evaluates boolean feature flag.
+//
+// It can be implemented with a client of OpenFeature or other feature flag provider.
+type FlagEvaluator interface {
+	BooleanValue(ctx context.Context, flagKey string) (bool, error)
+}
+
+// FlagEvaluatorFunc implements FlagEvaluator with a function.
+type FlagEvaluatorFunc func(ctx context.Context, flagKey string) (bool, error)
+
+// BooleanValue implements FlagEvaluator.
+func (f FlagEvaluatorFunc) BooleanValue(ctx context.Context, flagKey string) (bool, error) {
+	return f(ctx, flagKey)
+}
+
+// WithOpenFeature returns a logger that writes entries of any level when boolean flag is enabled.
+//
+// Flag evaluation is cached for Config.FeatureFlagTTL regardless of context, evaluation errors disable flag.
+func (l *Logger) WithOpenFeature(client FlagEvaluator, flagKey string) *Logger {
+	ttl := l.cfg.FeatureFlagTTL
+	if ttl == 0 {
+		ttl = time.Second
+	}
+
+	var (
+		mu      sync.Mutex
+		enabled bool
+		expires time.Time
+	)
+
+	nl := *l
+
+	nl.debugFlag = func(ctx context.Context) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if now := time.Now(); now.After(expires) {
+			v, err := client.BooleanValue(ctx, flagKey)
+			enabled = err == nil && v
+			expires = now.Add(ttl)
+		}
+
+		return enabled
+	}
+
+	return &nl
+}
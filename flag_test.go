@@ -0,0 +1,53 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithOpenFeature(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	var (
+		flag  = true
+		err   error
+		calls int
+	)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:      true,
+		Output:         w,
+		FeatureFlagTTL: time.Nanosecond,
+	}).WithOpenFeature(zapctxd.FlagEvaluatorFunc(func(_ context.Context, flagKey string) (bool, error) {
+		assert.Equal(t, "debug-logs", flagKey)
+
+		calls++
+
+		return flag, err
+	}), "debug-logs")
+
+	ctx := context.Background()
+
+	c.Debug(ctx, "enabled by flag")
+	c.Info(ctx, "enabled by level")
+
+	flag = false
+	time.Sleep(time.Millisecond)
+	c.Debug(ctx, "disabled by flag")
+
+	flag, err = true, errors.New("failed")
+	time.Sleep(time.Millisecond)
+	c.Debug(ctx, "disabled by error")
+
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, `{"level":"debug","time":"<stripped>","msg":"enabled by flag"}
+{"level":"info","time":"<stripped>","msg":"enabled by level"}
+`, w.String())
+}
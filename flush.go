@@ -0,0 +1,67 @@
+package zapctxd
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/bool64/ctxd"
+)
+
+// WithPeriodicFlush starts a background goroutine that syncs logger output every interval until ctx is done.
+//
+// It is useful with buffered outputs to deliver recent entries during quiet periods, logger is returned as is.
+func (l *Logger) WithPeriodicFlush(ctx context.Context, interval time.Duration) *Logger {
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				_ = l.Sync() //nolint:errcheck // Sync errors are not actionable in background.
+			}
+		}
+	}()
+
+	return l
+}
+
+type logWriterCtxKey struct{}
+
+// WithLogWriter returns context with custom log writer, as ctxd.WithLogWriter does.
+//
+// Writer of ctxd.WithLogWriter is wrapped by ctxd and only allows writes, while writer of
+// this function is also synced and closed if it implements io.Closer by Logger.WithContext.
+func WithLogWriter(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctxd.WithLogWriter(ctx, w), logWriterCtxKey{}, w)
+}
+
+// WithContext syncs logger output when ctx is done.
+//
+// It ensures buffered entries are flushed on cancellation or timeout of request, logger is returned as is.
+// Writer of WithLogWriter in ctx is synced too and closed if it implements io.Closer.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if ctx.Done() == nil {
+		return l
+	}
+
+	afterDone(ctx, func() {
+		_ = l.Sync() //nolint:errcheck // Sync errors are not actionable in background.
+
+		w, ok := ctx.Value(logWriterCtxKey{}).(io.Writer)
+		if !ok {
+			return
+		}
+
+		_ = syncWriter(w) //nolint:errcheck // Sync errors are not actionable in background.
+
+		if c, ok := w.(io.Closer); ok {
+			_ = c.Close() //nolint:errcheck // Close errors are not actionable in background.
+		}
+	})
+
+	return l
+}
@@ -0,0 +1,10 @@
+//go:build go1.21
+
+package zapctxd
+
+import "context"
+
+// afterDone calls f in its own goroutine after ctx is done.
+func afterDone(ctx context.Context, f func()) {
+	context.AfterFunc(ctx, f)
+}
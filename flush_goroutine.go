@@ -0,0 +1,15 @@
+//go:build !go1.21
+
+package zapctxd
+
+import "context"
+
+// afterDone calls f in its own goroutine after ctx is done.
+//
+// Without context.AfterFunc a goroutine waits for ctx during its lifetime.
+func afterDone(ctx context.Context, f func()) {
+	go func() {
+		<-ctx.Done()
+		f()
+	}()
+}
@@ -0,0 +1,104 @@
+package zapctxd_test
+
+import (
+	"bufio"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+type bufferedOutput struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+func (b *bufferedOutput) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.w.Write(p)
+}
+
+func (b *bufferedOutput) Sync() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.w.Flush()
+}
+
+func TestLogger_WithPeriodicFlush(t *testing.T) {
+	w := &syncBuffer{}
+	out := &bufferedOutput{w: bufio.NewWriterSize(w, 4096)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    out,
+	}).WithPeriodicFlush(ctx, 10*time.Millisecond)
+
+	c.Info(context.Background(), "hello")
+
+	assert.Eventually(t, func() bool {
+		return w.String() == `{"level":"info","time":"<stripped>","msg":"hello"}`+"\n"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestLogger_WithContext(t *testing.T) {
+	w := &syncBuffer{}
+	out := &bufferedOutput{w: bufio.NewWriterSize(w, 4096)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    out,
+	}).WithContext(ctx)
+
+	c.Info(ctx, "hello")
+	assert.Empty(t, w.String())
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		return w.String() == `{"level":"info","time":"<stripped>","msg":"hello"}`+"\n"
+	}, time.Second, 5*time.Millisecond)
+}
+
+type closingOutput struct {
+	bufferedOutput
+	closed atomic.Bool
+}
+
+func (c *closingOutput) Close() error {
+	c.closed.Store(true)
+
+	return nil
+}
+
+func TestLogger_WithContext_logWriter(t *testing.T) {
+	w := &syncBuffer{}
+	out := &closingOutput{bufferedOutput: bufferedOutput{w: bufio.NewWriterSize(w, 4096)}}
+
+	ctx, cancel := context.WithCancel(zapctxd.WithLogWriter(context.Background(), out))
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+	}).WithContext(ctx)
+
+	c.Info(ctx, "hello")
+	assert.Empty(t, w.String())
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		return out.closed.Load() && w.String() == `{"level":"info","time":"<stripped>","msg":"hello"}`+"\n"
+	}, time.Second, 5*time.Millisecond)
+}
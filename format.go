@@ -0,0 +1,57 @@
+package zapctxd
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	encodingJSON    = "json"
+	encodingConsole = "console"
+	encodingLogfmt  = "logfmt"
+)
+
+// SetFormat switches output format of a running logger, format can be "json", "console" or "logfmt".
+func (l *Logger) SetFormat(format string) error {
+	if l.mu == nil {
+		return errors.New("cannot set format when logger is created with zap loggers")
+	}
+
+	switch format {
+	case encodingJSON, encodingConsole, encodingLogfmt:
+	default:
+		return fmt.Errorf("unknown log format %q, json, console or logfmt expected", format)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.format = format
+	l.encoder = newEncoder(l.cfg, format, l.encoderOptions...)
+	l.make()
+
+	return nil
+}
+
+// WithLogfmtEncoder returns a copy of logger that writes entries in logfmt format.
+//
+// Nested objects are flattened with dot-separated keys, arrays are encoded as JSON.
+// Logger created with zap loggers is returned unchanged.
+func (l *Logger) WithLogfmtEncoder() *Logger {
+	if l.mu == nil {
+		return l
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	nl := *l
+
+	nl.mu = &sync.RWMutex{}
+	nl.format = encodingLogfmt
+	nl.encoder = newEncoder(l.cfg, encodingLogfmt, l.encoderOptions...)
+	nl.make()
+
+	return &nl
+}
@@ -0,0 +1,64 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_SetFormat(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "foo", "bar")
+
+	c.Info(ctx, "json")
+	require.NoError(t, c.SetFormat("console"))
+	c.Info(ctx, "console")
+	require.NoError(t, c.SetFormat("json"))
+	c.Info(ctx, "json again")
+
+	assert.EqualError(t, c.SetFormat("xml"), `unknown log format "xml", json, console or logfmt expected`)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"json","foo":"bar"}
+<stripped>	INFO	console	{"foo": "bar"}
+{"level":"info","time":"<stripped>","msg":"json again","foo":"bar"}
+`, w.String())
+}
+
+func TestLogger_SetFormat_concurrency(t *testing.T) {
+	c := zapctxd.New(zapctxd.Config{
+		Output: &syncBuffer{},
+	})
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			c.Info(context.Background(), "hello")
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, c.SetFormat("console"))
+		}()
+	}
+
+	wg.Wait()
+}
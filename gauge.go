@@ -0,0 +1,21 @@
+package zapctxd
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithLevelGauge returns a logger that calls fn after every written entry with entry level
+// and cumulative count of entries of that level written by the logger.
+//
+// It allows bridging log rates to any metrics system.
+func (l *Logger) WithLevelGauge(fn func(level zapcore.Level, count int64)) *Logger {
+	var counts [zapcore.FatalLevel - zapcore.DebugLevel + 1]atomic.Int64
+
+	return l.withHook(func(e entry) {
+		if e.level >= zapcore.DebugLevel && e.level <= zapcore.FatalLevel {
+			fn(e.level, counts[e.level-zapcore.DebugLevel].Add(1))
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithLevelGauge(t *testing.T) {
+	var calls []string
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	}).WithLevelGauge(func(level zapcore.Level, count int64) {
+		calls = append(calls, level.String()+":"+strconv.FormatInt(count, 10))
+	})
+
+	ctx := context.Background()
+
+	c.Info(ctx, "one")
+	c.Debug(ctx, "skipped")
+	c.Error(ctx, "two")
+	c.Info(ctx, "three")
+
+	assert.Equal(t, []string{"info:1", "error:1", "info:2"}, calls)
+}
@@ -0,0 +1,36 @@
+package zapctxd
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// WithGoroutineID returns a logger that adds "goroutine_id" field with an identifier of goroutine that emits entry.
+//
+// Identifier is parsed from runtime.Stack, this is relatively expensive and should not be used
+// in performance-sensitive code, it is intended for debugging of concurrency issues.
+func (l *Logger) WithGoroutineID() *Logger {
+	return l.withProcessor(addGoroutineID)
+}
+
+func addGoroutineID(e entry) []any {
+	return append(e.kv, "goroutine_id", goroutineID())
+}
+
+func goroutineID() uint64 {
+	var buf [64]byte
+
+	// Stack starts with "goroutine 123 [running]:".
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
@@ -0,0 +1,59 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithGoroutineID(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:   true,
+		Output:      w,
+		GoroutineID: true,
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			c.Info(context.Background(), "hello")
+		}()
+	}
+
+	wg.Wait()
+
+	ids := map[uint64]bool{}
+
+	for _, line := range strings.Split(strings.TrimSpace(w.String()), "\n") {
+		var e struct {
+			GoroutineID uint64 `json:"goroutine_id"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		assert.NotZero(t, e.GoroutineID)
+
+		ids[e.GoroutineID] = true
+	}
+
+	assert.Len(t, ids, 2)
+
+	b := bytes.NewBuffer(nil)
+
+	zapctxd.New(zapctxd.Config{Output: b}).WithGoroutineID().Info(context.Background(), "hi")
+	assert.Contains(t, b.String(), `"goroutine_id":`)
+}
@@ -0,0 +1,152 @@
+// Package grpclog provides gRPC server interceptors for contextualized logging.
+package grpclog
+
+import (
+	"context"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/bool64/zapctxd"
+)
+
+// InterceptorOption configures interceptors.
+type InterceptorOption func(o *options)
+
+type options struct {
+	requestIDKey string
+	level        func(code codes.Code) zapcore.Level
+}
+
+// WithRequestIDMetadata sets metadata key of request ID, default "x-request-id".
+func WithRequestIDMetadata(key string) InterceptorOption {
+	return func(o *options) {
+		o.requestIDKey = key
+	}
+}
+
+// WithStatusLevel sets mapping of status code to level of "rpc served" entry,
+// default is info for codes.OK and error otherwise.
+func WithStatusLevel(level func(code codes.Code) zapcore.Level) InterceptorOption {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+func defaultLevel(code codes.Code) zapcore.Level {
+	if code == codes.OK {
+		return zap.InfoLevel
+	}
+
+	return zap.ErrorLevel
+}
+
+func newOptions(opts []InterceptorOption) options {
+	o := options{
+		requestIDKey: "x-request-id",
+		level:        defaultLevel,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// UnaryServerInterceptor returns an interceptor that logs served unary calls.
+//
+// Context of call has "grpc_method", "peer" and "request_id" (if available) fields, so that
+// entries logged by handler are correlated. After handler returns, "rpc served" entry is logged
+// with "grpc_code" and "latency_ms" fields.
+func UnaryServerInterceptor(l *zapctxd.Logger, opts ...InterceptorOption) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = o.context(ctx, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+
+		o.served(ctx, l, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that logs served streaming calls.
+//
+// Context of stream has "grpc_method", "peer" and "request_id" (if available) fields, so that
+// entries logged by handler are correlated. After handler returns, "rpc served" entry is logged
+// with "grpc_code" and "latency_ms" fields.
+func StreamServerInterceptor(l *zapctxd.Logger, opts ...InterceptorOption) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := o.context(ss.Context(), info.FullMethod)
+
+		err := handler(srv, contextStream{ServerStream: ss, ctx: ctx})
+
+		o.served(ctx, l, start, err)
+
+		return err
+	}
+}
+
+func (o options) context(ctx context.Context, method string) context.Context {
+	fields := []any{"grpc_method", method}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, "peer", p.Addr.String())
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(o.requestIDKey); len(v) > 0 && v[0] != "" {
+			fields = append(fields, "request_id", v[0])
+		}
+	}
+
+	return ctxd.AddFields(ctx, fields...)
+}
+
+func (o options) served(ctx context.Context, l *zapctxd.Logger, start time.Time, err error) {
+	code := status.Code(err)
+
+	kv := []any{
+		"grpc_code", code.String(),
+		"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		kv = append(kv, "error", err)
+	}
+
+	switch o.level(code) {
+	case zap.DebugLevel:
+		l.Debug(ctx, "rpc served", kv...)
+	case zap.InfoLevel:
+		l.Info(ctx, "rpc served", kv...)
+	case zap.WarnLevel:
+		l.Warn(ctx, "rpc served", kv...)
+	default:
+		l.Error(ctx, "rpc served", kv...)
+	}
+}
+
+// contextStream overrides context of server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context //nolint:containedctx // Stream is bound to context.
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
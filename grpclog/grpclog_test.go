@@ -0,0 +1,127 @@
+package grpclog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/grpclog"
+)
+
+func incomingContext() context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("x-request-id", "abc"))
+}
+
+func served(t *testing.T, line string) map[string]any {
+	t.Helper()
+
+	var e map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(line), &e))
+	assert.Contains(t, e, "latency_ms")
+	delete(e, "latency_ms")
+
+	return e
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	l := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	i := grpclog.UnaryServerInterceptor(l)
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.Users/Get"}
+
+	resp, err := i(incomingContext(), "req", info, func(ctx context.Context, req any) (any, error) {
+		l.Info(ctx, "handling")
+
+		return "resp", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "resp", resp)
+
+	_, err = i(context.Background(), "req", info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "no user")
+	})
+	require.Error(t, err)
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"handling","grpc_method":"/users.Users/Get","peer":"10.0.0.1:5000","request_id":"abc"}`, lines[0])
+	assert.Equal(t, map[string]any{
+		"level": "info", "time": "<stripped>", "msg": "rpc served", "grpc_code": "OK",
+		"grpc_method": "/users.Users/Get", "peer": "10.0.0.1:5000", "request_id": "abc",
+	}, served(t, lines[1]))
+	assert.Equal(t, map[string]any{
+		"level": "error", "time": "<stripped>", "msg": "rpc served", "grpc_code": "NotFound",
+		"grpc_method": "/users.Users/Get", "error": "rpc error: code = NotFound desc = no user",
+	}, served(t, lines[2]))
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context //nolint:containedctx
+}
+
+func (s serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	l := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	i := grpclog.StreamServerInterceptor(l,
+		grpclog.WithRequestIDMetadata("x-trace"),
+		grpclog.WithStatusLevel(func(code codes.Code) zapcore.Level {
+			if code == codes.Canceled {
+				return zap.WarnLevel
+			}
+
+			return zap.InfoLevel
+		}),
+	)
+	info := &grpc.StreamServerInfo{FullMethod: "/users.Users/List"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-trace", "def"))
+
+	err := i(nil, serverStream{ctx: ctx}, info, func(srv any, ss grpc.ServerStream) error {
+		l.Info(ss.Context(), "streaming")
+
+		return status.Error(codes.Canceled, "client gone")
+	})
+	require.Error(t, err)
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	require.Len(t, lines, 2)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"streaming","grpc_method":"/users.Users/List","request_id":"def"}`, lines[0])
+	assert.Equal(t, map[string]any{
+		"level": "warn", "time": "<stripped>", "msg": "rpc served", "grpc_code": "Canceled",
+		"grpc_method": "/users.Users/List", "request_id": "def", "error": "rpc error: code = Canceled desc = client gone",
+	}, served(t, lines[1]))
+}
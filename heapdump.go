@@ -0,0 +1,65 @@
+package zapctxd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// heapCheckInterval limits frequency of heap checks in Logger.WithHeapDump.
+const heapCheckInterval = time.Minute
+
+// WithHeapDump returns a logger that writes heap profile to dir/<timestamp>-heap.pprof
+// if heap allocation exceeds threshold bytes when an entry of Error level or above is written.
+//
+// Heap is checked at most once per minute, profile is written in background.
+func (l *Logger) WithHeapDump(dir string, threshold uint64) *Logger {
+	var lastCheck atomic.Int64
+
+	return l.withHook(func(e entry) {
+		if e.level < zap.ErrorLevel {
+			return
+		}
+
+		now := time.Now()
+		last := lastCheck.Load()
+
+		if (last != 0 && now.Sub(time.Unix(0, last)) < heapCheckInterval) || !lastCheck.CompareAndSwap(last, now.UnixNano()) {
+			return
+		}
+
+		var m runtime.MemStats
+
+		runtime.ReadMemStats(&m)
+
+		if m.HeapAlloc <= threshold {
+			return
+		}
+
+		go func() {
+			if err := heapProfile(dir, now); err != nil {
+				l.sugared.Warnw("failed to write heap profile", "error", err.Error(), "entry_msg", e.msg)
+			}
+		}()
+	})
+}
+
+func heapProfile(dir string, now time.Time) error {
+	f, err := os.Create(filepath.Join(dir, now.UTC().Format("20060102T150405.000000000")+"-heap.pprof")) //nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		_ = f.Close() //nolint:errcheck // Error of write is reported instead.
+
+		return err
+	}
+
+	return f.Close()
+}
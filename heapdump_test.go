@@ -0,0 +1,41 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithHeapDump(t *testing.T) {
+	dir := t.TempDir()
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	}).WithHeapDump(dir, 1)
+
+	c.Error(context.Background(), "dumped")
+	c.Error(context.Background(), "rate limited")
+
+	assert.Eventually(t, func() bool {
+		files, err := filepath.Glob(filepath.Join(dir, "*-heap.pprof"))
+		if err != nil || len(files) == 0 {
+			return false
+		}
+
+		fi, err := os.Stat(files[0])
+
+		return err == nil && fi.Size() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*-heap.pprof"))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
@@ -0,0 +1,155 @@
+package zapctxd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// entry describes a log entry that passed level check.
+type entry struct {
+	ctx       context.Context //nolint:containedctx // Entry is short-lived.
+	level     zapcore.Level
+	important bool
+	msg       string
+	kv        []any
+}
+
+// detach sets a copy of key-value pairs to entry, so that they can be retained without forcing allocation on callers.
+func (e entry) detach(kv []any) entry {
+	e.kv = append(make([]any, 0, len(kv)), kv...)
+
+	return e
+}
+
+// process applies installed processors to key-value pairs.
+func (l *Logger) process(e entry, kv []any) []any {
+	e = e.detach(kv)
+
+	if l.seq != nil {
+		e.kv = append(e.kv, "seq", l.seq.Add(1))
+	}
+
+	for _, p := range l.processors {
+		e.kv = p(e)
+	}
+
+	return e.kv
+}
+
+// skip checks if entry should be dropped by any of installed filters.
+func (l *Logger) skip(e entry, kv []any) bool {
+	if len(l.filters) == 0 {
+		return false
+	}
+
+	e = e.detach(kv)
+
+	for _, f := range l.filters {
+		if f(e) {
+			l.metrics.drop()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// written notifies installed hooks about written entry.
+func (l *Logger) written(e entry, kv []any) {
+	l.metrics.write(e.level)
+
+	if l.firstError == nil && len(l.hooks) == 0 {
+		return
+	}
+
+	e = e.detach(kv)
+
+	if l.firstError != nil && e.level >= zap.ErrorLevel {
+		l.firstError.fire(e.ctx, e.msg, e.kv)
+	}
+
+	for _, h := range l.hooks {
+		h(e)
+	}
+}
+
+// withProcessor returns a copy of logger with an additional processor that transforms key-value pairs of entries.
+func (l *Logger) withProcessor(p func(e entry) []any) *Logger {
+	nl := *l
+
+	nl.processors = append(l.processors[:len(l.processors):len(l.processors)], p)
+
+	return &nl
+}
+
+// withFilter returns a copy of logger with an additional filter that returns true for entries to be dropped.
+func (l *Logger) withFilter(f func(e entry) bool) *Logger {
+	nl := *l
+
+	nl.filters = append(l.filters[:len(l.filters):len(l.filters)], f)
+
+	return &nl
+}
+
+// withHook returns a copy of logger with an additional hook called after entry is written.
+func (l *Logger) withHook(h func(e entry)) *Logger {
+	nl := *l
+
+	nl.hooks = append(l.hooks[:len(l.hooks):len(l.hooks)], h)
+
+	return &nl
+}
+
+// Hook receives written entries, it is installed with Config.Hooks or Logger.WithHook.
+type Hook interface {
+	// Fire is called synchronously after entry is written to output, fields include context fields.
+	Fire(level zapcore.Level, msg string, fields []zapcore.Field) error
+}
+
+// WithHook returns a copy of logger with an additional hook that receives written entries.
+func (l *Logger) WithHook(h Hook) *Logger {
+	return l.withHook(l.fireHook(h))
+}
+
+// fireHook returns a hook that calls h with entry fields and reports its failures.
+func (l *Logger) fireHook(h Hook) func(e entry) {
+	return func(e entry) {
+		fields := make([]zapcore.Field, 0, len(e.kv)/2)
+
+		for i := 0; i < len(e.kv)-1; i += 2 {
+			k, ok := e.kv[i].(string)
+			if !ok {
+				k = fmt.Sprint(e.kv[i])
+			}
+
+			fields = append(fields, zap.Any(k, e.kv[i+1]))
+		}
+
+		if err := h.Fire(e.level, e.msg, fields); err != nil {
+			l.sugared.Warnw("log hook failed", "error", err.Error(), "entry_msg", e.msg)
+		}
+	}
+}
+
+type onceHook struct {
+	once sync.Once
+	fn   func(ctx context.Context, msg string, keysAndValues []any)
+}
+
+func (h *onceHook) fire(ctx context.Context, msg string, keysAndValues []any) {
+	h.once.Do(func() {
+		h.fn(ctx, msg, keysAndValues)
+	})
+}
+
+// OnFirstError installs a one-shot hook that is called on the first written error entry.
+//
+// Hook receives the message and merged key-value pairs of the entry, subsequent errors are unaffected.
+func (l *Logger) OnFirstError(fn func(ctx context.Context, msg string, keysAndValues []any)) {
+	l.firstError = &onceHook{fn: fn}
+}
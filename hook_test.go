@@ -0,0 +1,134 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestLogger_OnFirstError(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	var (
+		calls int
+		msg   string
+		kv    []any
+	)
+
+	c.OnFirstError(func(_ context.Context, m string, keysAndValues []any) {
+		calls++
+		msg = m
+		kv = keysAndValues
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "foo", "bar")
+
+	c.Warn(ctx, "not an error")
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			c.Error(ctx, "failed", "baz", 1)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "failed", msg)
+	assert.Equal(t, []any{"baz", 1, "foo", "bar"}, kv)
+}
+
+type hookFunc func(level zapcore.Level, msg string, fields []zapcore.Field) error
+
+func (f hookFunc) Fire(level zapcore.Level, msg string, fields []zapcore.Field) error {
+	return f(level, msg, fields)
+}
+
+func TestConfig_Hooks(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	var fired []string
+
+	c := zapctxd.New(zapctxd.Config{
+		Output:    w,
+		StripTime: true,
+		Hooks: []zapctxd.Hook{hookFunc(func(level zapcore.Level, msg string, fields []zapcore.Field) error {
+			enc := zapcore.NewMapObjectEncoder()
+
+			for _, f := range fields {
+				f.AddTo(enc)
+			}
+
+			fired = append(fired, level.String()+" "+msg+" "+enc.Fields["user"].(string))
+
+			return errors.New("failed")
+		})},
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "user", "john")
+
+	c.Debug(ctx, "hidden")
+	c.Error(ctx, "oops")
+
+	assert.Equal(t, []string{"error oops john"}, fired)
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"oops","user":"john"}
+{"level":"warn","time":"<stripped>","msg":"log hook failed","error":"failed","entry_msg":"oops"}
+`, w.String())
+}
+
+func TestLogger_WithHook(t *testing.T) {
+	var fired []string
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	})
+
+	h := c.WithHook(hookFunc(func(level zapcore.Level, msg string, _ []zapcore.Field) error {
+		fired = append(fired, level.String()+" "+msg)
+
+		return nil
+	}))
+
+	c.Info(context.Background(), "parent")
+	h.Info(context.Background(), "hooked")
+	h.With("foo", "bar").Warn(context.Background(), "derived")
+
+	assert.Equal(t, []string{"info hooked", "warn derived"}, fired)
+}
@@ -0,0 +1,179 @@
+// Package httplog provides net/http middleware for contextualized logging.
+package httplog
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/bool64/zapctxd"
+)
+
+// MiddlewareOption configures Middleware.
+type MiddlewareOption func(o *options)
+
+type options struct {
+	requestIDHeader string
+	skipPaths       map[string]bool
+	bodyMaxBytes    int
+}
+
+// WithRequestIDHeader sets header name of request ID, default "X-Request-ID".
+func WithRequestIDHeader(name string) MiddlewareOption {
+	return func(o *options) {
+		o.requestIDHeader = name
+	}
+}
+
+// WithSkipPaths disables logging of requests with paths, e.g. health checks.
+func WithSkipPaths(paths []string) MiddlewareOption {
+	return func(o *options) {
+		for _, p := range paths {
+			o.skipPaths[p] = true
+		}
+	}
+}
+
+// WithRequestBodyLog adds "request_body" field with up to maxBytes of request body.
+func WithRequestBodyLog(maxBytes int) MiddlewareOption {
+	return func(o *options) {
+		o.bodyMaxBytes = maxBytes
+	}
+}
+
+// Middleware returns a middleware that logs served requests with info level.
+//
+// Context of request has "method", "path" and "request_id" (if available) fields, so that
+// entries logged by handler are correlated. After handler returns, "request served" entry is logged
+// with "status" and "latency_ms" fields.
+func Middleware(l *zapctxd.Logger, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	o := options{
+		requestIDHeader: "X-Request-ID",
+		skipPaths:       map[string]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if o.skipPaths[r.URL.Path] {
+				next.ServeHTTP(rw, r)
+
+				return
+			}
+
+			start := time.Now()
+
+			fields := []any{"method", r.Method, "path", r.URL.Path}
+
+			if id := r.Header.Get(o.requestIDHeader); id != "" {
+				fields = append(fields, "request_id", id)
+			}
+
+			ctx := ctxd.AddFields(r.Context(), fields...)
+
+			var body []byte
+
+			if o.bodyMaxBytes > 0 && r.Body != nil {
+				var err error
+
+				body, err = io.ReadAll(io.LimitReader(r.Body, int64(o.bodyMaxBytes)))
+				if err != nil {
+					l.Warn(ctx, "failed to read request body", "error", err)
+				}
+
+				r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+			}
+
+			sw := &statusWriter{ResponseWriter: rw}
+
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			kv := []any{
+				"status", sw.status(),
+				"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			}
+
+			if body != nil {
+				kv = append(kv, "request_body", string(body))
+			}
+
+			l.Info(ctx, "request served", kv...)
+		})
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// statusWriter captures status code of response.
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher if underlying writer supports it.
+func (w *statusWriter) Flush() {
+	f, ok := w.ResponseWriter.(http.Flusher)
+	if !ok {
+		return
+	}
+
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+
+	f.Flush()
+}
+
+// Hijack implements http.Hijacker, it fails if underlying writer does not support hijacking.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking is not supported by response writer")
+	}
+
+	if w.code == 0 {
+		w.code = http.StatusSwitchingProtocols
+	}
+
+	return h.Hijack()
+}
+
+// Unwrap allows http.ResponseController to access underlying writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *statusWriter) status() int {
+	if w.code == 0 {
+		return http.StatusOK
+	}
+
+	return w.code
+}
@@ -0,0 +1,162 @@
+package httplog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/httplog"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestMiddleware(t *testing.T) {
+	w := &syncBuffer{}
+
+	l := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	h := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		l.Info(r.Context(), "handling", "body_size", len(body))
+
+		if r.URL.Path == "/missing" {
+			rw.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, err = rw.Write([]byte("ok"))
+		assert.NoError(t, err)
+	})
+
+	srv := httptest.NewServer(httplog.Middleware(l,
+		httplog.WithRequestIDHeader("X-Trace"),
+		httplog.WithSkipPaths([]string{"/health"}),
+		httplog.WithRequestBodyLog(5),
+	)(h))
+	defer srv.Close()
+
+	do := func(method, path, body string, header http.Header) {
+		req, err := http.NewRequestWithContext(context.Background(), method, srv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	do(http.MethodPost, "/users", "hello world", http.Header{"X-Trace": {"abc"}})
+	do(http.MethodGet, "/missing", "", nil)
+	do(http.MethodGet, "/health", "", nil)
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	require.Len(t, lines, 5)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"handling","body_size":11,"method":"POST","path":"/users","request_id":"abc"}`, lines[0])
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"handling","body_size":0}`, lines[4])
+
+	var served struct {
+		Msg         string  `json:"msg"`
+		Method      string  `json:"method"`
+		Path        string  `json:"path"`
+		RequestID   string  `json:"request_id"`
+		Status      int     `json:"status"`
+		LatencyMS   float64 `json:"latency_ms"`
+		RequestBody string  `json:"request_body"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &served))
+	assert.Equal(t, "request served", served.Msg)
+	assert.Equal(t, "POST", served.Method)
+	assert.Equal(t, "/users", served.Path)
+	assert.Equal(t, "abc", served.RequestID)
+	assert.Equal(t, http.StatusOK, served.Status)
+	assert.Equal(t, "hello", served.RequestBody)
+	assert.GreaterOrEqual(t, served.LatencyMS, 0.0)
+
+	served.RequestID = ""
+
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &served))
+	assert.Equal(t, "/missing", served.Path)
+	assert.Equal(t, http.StatusNotFound, served.Status)
+	assert.Empty(t, served.RequestID)
+}
+
+func TestMiddleware_flushHijack(t *testing.T) {
+	w := &syncBuffer{}
+
+	l := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	h := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/flush" {
+			rw.(http.Flusher).Flush()
+
+			return
+		}
+
+		conn, buf, err := rw.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+
+		_, err = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 0\r\nConnection: close\r\n\r\n")
+		assert.NoError(t, err)
+		assert.NoError(t, buf.Flush())
+		assert.NoError(t, conn.Close())
+	})
+
+	rec := httptest.NewRecorder()
+	httplog.Middleware(l)(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flush", nil))
+	assert.True(t, rec.Flushed)
+
+	srv := httptest.NewServer(httplog.Middleware(l)(h))
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/hijack", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Contains(t, w.String(), `"msg":"request served","status":200`)
+	assert.Contains(t, w.String(), `"msg":"request served","status":101`)
+}
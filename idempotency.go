@@ -0,0 +1,43 @@
+package zapctxd
+
+import (
+	"context"
+	"time"
+)
+
+const idempotencyCacheSize = 10000
+
+// WithIdempotencyKey returns a logger that suppresses repeated entries for the same idempotency key.
+//
+// Key is extracted from context with fn, an entry with the same key, level and message is written only once
+// within Config.IdempotencyTTL. Entries with empty key are not suppressed.
+func (l *Logger) WithIdempotencyKey(fn func(ctx context.Context) string) *Logger {
+	ttl := l.idempotencyTTL
+	if ttl == 0 {
+		ttl = time.Minute
+	}
+
+	seen := newLRU[time.Time](idempotencyCacheSize)
+
+	return l.withFilter(func(e entry) bool {
+		key := fn(e.ctx)
+		if key == "" {
+			return false
+		}
+
+		now := time.Now()
+		dup := false
+
+		seen.update(key+"\x00"+e.level.String()+"\x00"+e.msg, func(expires time.Time, found bool) time.Time {
+			if found && now.Before(expires) {
+				dup = true
+
+				return expires
+			}
+
+			return now.Add(ttl)
+		})
+
+		return dup
+	})
+}
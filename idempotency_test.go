@@ -0,0 +1,44 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+type requestIDKey struct{}
+
+func TestLogger_WithIdempotencyKey(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithIdempotencyKey(func(ctx context.Context) string {
+		id, _ := ctx.Value(requestIDKey{}).(string)
+
+		return id
+	})
+
+	ctx1 := context.WithValue(context.Background(), requestIDKey{}, "req1")
+	ctx2 := context.WithValue(context.Background(), requestIDKey{}, "req2")
+
+	for i := 0; i < 3; i++ {
+		c.Info(ctx1, "handling", "attempt", i)
+		c.Error(ctx1, "handling", "attempt", i)
+		c.Info(ctx2, "handling", "attempt", i)
+		c.Info(context.Background(), "no key", "attempt", i)
+	}
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"handling","attempt":0}
+{"level":"error","time":"<stripped>","msg":"handling","attempt":0}
+{"level":"info","time":"<stripped>","msg":"handling","attempt":0}
+{"level":"info","time":"<stripped>","msg":"no key","attempt":0}
+{"level":"info","time":"<stripped>","msg":"no key","attempt":1}
+{"level":"info","time":"<stripped>","msg":"no key","attempt":2}
+`, w.String())
+}
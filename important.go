@@ -0,0 +1,199 @@
+package zapctxd
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// asyncQueueSize is a capacity of queue for Logger.AsyncImportant.
+const asyncQueueSize = 1024
+
+type asyncEntry struct {
+	l      *Logger
+	ctx    context.Context //nolint:containedctx // Entry is short-lived.
+	msg    string
+	kv     []any
+	caller zapcore.EntryCaller
+
+	// synced is closed when queue is drained up to this entry.
+	synced chan struct{}
+}
+
+// asyncQueue is drained by a background goroutine started on first use.
+type asyncQueue struct {
+	once  sync.Once
+	queue chan asyncEntry
+	done  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func (q *asyncQueue) start() {
+	q.once.Do(func() {
+		q.queue = make(chan asyncEntry, asyncQueueSize)
+		q.done = make(chan struct{})
+
+		go func() {
+			defer close(q.done)
+
+			for e := range q.queue {
+				if e.synced != nil {
+					close(e.synced)
+
+					continue
+				}
+
+				e.write()
+			}
+		}()
+	})
+}
+
+// sync waits for queued entries to be written.
+func (q *asyncQueue) sync() {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed || q.queue == nil {
+		return
+	}
+
+	synced := make(chan struct{})
+	q.queue <- asyncEntry{synced: synced}
+
+	<-synced
+}
+
+// close writes queued entries and stops background goroutine.
+func (q *asyncQueue) close() {
+	q.mu.Lock()
+
+	if q.closed {
+		q.mu.Unlock()
+
+		return
+	}
+
+	q.closed = true
+
+	if q.queue == nil {
+		q.mu.Unlock()
+
+		return
+	}
+
+	close(q.queue)
+	q.mu.Unlock()
+
+	<-q.done
+}
+
+func (e asyncEntry) write() {
+	e.l.withEntryCaller(e.caller).Important(e.ctx, e.msg, e.kv...)
+}
+
+// AsyncImportant logs important message without blocking on output.
+//
+// Entry is queued and written by a background goroutine, if queue is full,
+// entry is logged synchronously with "async_overflow": true field.
+// Caller is captured before entry is queued. Logger.Sync waits for queued entries,
+// after Logger.Close entries are logged synchronously.
+func (l *Logger) AsyncImportant(ctx context.Context, msg string, keysAndValues ...any) {
+	e := asyncEntry{l: l, ctx: ctx, msg: msg, kv: keysAndValues}
+
+	if l.caller {
+		e.caller = entryCaller(1 + l.callerFrames)
+	}
+
+	q := l.asyncQueue
+	if q == nil {
+		e.write()
+
+		return
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		e.write()
+
+		return
+	}
+
+	q.start()
+
+	e.kv = append(make([]any, 0, len(keysAndValues)+2), keysAndValues...)
+
+	select {
+	case q.queue <- e:
+	default:
+		e.kv = append(e.kv, "async_overflow", true)
+		e.write()
+	}
+}
+
+// entryCaller returns caller as runtime.Caller(skip) of the function that called entryCaller.
+func entryCaller(skip int) zapcore.EntryCaller {
+	var pcs [1]uintptr
+
+	// Skipping runtime.Callers and entryCaller.
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return zapcore.EntryCaller{}
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+
+	return zapcore.EntryCaller{
+		Defined:  frame.PC != 0,
+		PC:       frame.PC,
+		File:     frame.File,
+		Line:     frame.Line,
+		Function: frame.Function,
+	}
+}
+
+// withEntryCaller returns logger that reports given caller instead of call site of logging method.
+func (l *Logger) withEntryCaller(caller zapcore.EntryCaller) *Logger {
+	if !caller.Defined {
+		return l
+	}
+
+	opts := []zap.Option{
+		zap.WithCaller(false),
+		zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return callerCore{Core: c, caller: caller}
+		}),
+	}
+
+	nl := *l
+
+	nl.options = append(l.options[:len(l.options):len(l.options)], opts...)
+	nl.zl = l.zl.WithOptions(opts...)
+	nl.zdebug = l.zdebug.WithOptions(opts...)
+	nl.sugared = nl.zl.Sugar()
+	nl.debug = nl.zdebug.Sugar()
+
+	return &nl
+}
+
+// callerCore sets predefined caller to checked entries.
+type callerCore struct {
+	zapcore.Core
+	caller zapcore.EntryCaller
+}
+
+func (c callerCore) With(fields []zapcore.Field) zapcore.Core { //nolint:ireturn // Core is a zap interface.
+	return callerCore{Core: c.Core.With(fields), caller: c.caller}
+}
+
+func (c callerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	ent.Caller = c.caller
+
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,98 @@
+package zapctxd_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_AsyncImportant(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+		Level:     zapcore.WarnLevel,
+	})
+
+	kv := []any{"amount", 10}
+	c.AsyncImportant(ctxd.AddFields(context.Background(), "user", "john"), "payment completed", kv...)
+	kv[1] = 20
+
+	assert.Eventually(t, func() bool {
+		return w.String() == `{"level":"info","time":"<stripped>","msg":"payment completed","amount":10,"user":"john"}`+"\n"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestLogger_AsyncImportant_overflow(t *testing.T) {
+	w := &slowWriter{delay: time.Millisecond}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	for i := 0; i < 2000; i++ {
+		c.AsyncImportant(context.Background(), "event")
+	}
+
+	assert.Eventually(t, func() bool {
+		return strings.Count(w.String(), "\n") == 2000
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Contains(t, w.String(), `"async_overflow":true`)
+}
+
+func TestLogger_AsyncImportant_caller(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithCaller(0)
+
+	wrapper := func(c *zapctxd.Logger) {
+		c.WithCaller(1).AsyncImportant(context.Background(), "wrapped")
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+
+	c.AsyncImportant(context.Background(), "queued")
+	wrapper(c)
+	assert.NoError(t, c.Sync())
+
+	assert.Equal(t, fmt.Sprintf(`{"level":"info","time":"<stripped>","caller":"zapctxd/important_test.go:%d","msg":"queued"}
+{"level":"info","time":"<stripped>","caller":"zapctxd/important_test.go:%d","msg":"wrapped"}
+`, line+2, line+3), w.String())
+}
+
+func TestLogger_AsyncImportant_close(t *testing.T) {
+	w := &slowWriter{delay: time.Millisecond}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	for i := 0; i < 10; i++ {
+		c.AsyncImportant(context.Background(), "event")
+	}
+
+	assert.NoError(t, c.Sync())
+	assert.Equal(t, 10, strings.Count(w.String(), "\n"))
+
+	c.AsyncImportant(context.Background(), "event")
+	assert.NoError(t, c.Close())
+	assert.Equal(t, 11, strings.Count(w.String(), "\n"))
+
+	c.AsyncImportant(context.Background(), "closed")
+	assert.Contains(t, w.String(), `"msg":"closed"`)
+}
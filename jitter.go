@@ -0,0 +1,49 @@
+package zapctxd
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithJitter returns a copy of logger that adds a random duration in [0, maxJitter] to entry timestamps.
+//
+// Jitter is generated with crypto/rand to prevent timing analysis of logs.
+// Logger created with zap loggers is returned unchanged.
+func (l *Logger) WithJitter(maxJitter time.Duration) *Logger {
+	if l.mu == nil || maxJitter <= 0 {
+		return l
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	nl := *l
+
+	nl.mu = &sync.RWMutex{}
+	nl.encoderOptions = append(l.encoderOptions[:len(l.encoderOptions):len(l.encoderOptions)], func(ec *zapcore.EncoderConfig) {
+		encodeTime := ec.EncodeTime
+
+		ec.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			encodeTime(t.Add(jitter(maxJitter)), enc)
+		}
+	})
+	nl.encoder = newEncoder(l.cfg, l.format, nl.encoderOptions...)
+	nl.make()
+
+	return &nl
+}
+
+// jitter returns a random duration in [0, maxJitter].
+func jitter(maxJitter time.Duration) time.Duration {
+	var b [8]byte
+
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0
+	}
+
+	return time.Duration(binary.LittleEndian.Uint64(b[:]) % (uint64(maxJitter) + 1))
+}
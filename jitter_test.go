@@ -0,0 +1,53 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithJitter(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: w,
+	}).WithJitter(time.Hour)
+
+	for i := 0; i < 10; i++ {
+		before := time.Now().Truncate(time.Millisecond)
+
+		c.Info(context.Background(), "hello")
+
+		line := strings.TrimSpace(w.String())
+		w.Reset()
+
+		var e struct {
+			Time string `json:"time"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+
+		ts, err := time.Parse("2006-01-02T15:04:05.000Z0700", e.Time)
+		require.NoError(t, err)
+
+		assert.False(t, ts.Before(before))
+		assert.False(t, ts.After(time.Now().Add(time.Hour)))
+	}
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithJitter(time.Hour)
+
+	c.Info(context.Background(), "hello")
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello"}
+`, w.String())
+}
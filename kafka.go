@@ -0,0 +1,41 @@
+package zapctxd
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+const kafkaQueueSize = 10000
+
+// KafkaProducer sends messages to Kafka topic.
+//
+// It can be implemented with a client library, e.g. github.com/IBM/sarama.
+type KafkaProducer interface {
+	Produce(topic string, key, value []byte) error
+}
+
+// WithKafkaOutput returns a clone of logger that produces entries as messages to Kafka topic.
+//
+// Level name is used as message key. Messages are produced asynchronously, when queue is full
+// the oldest entries are dropped and counted in LoggerMetrics.DroppedEntries.
+// Original logger is not affected, Close of the clone stops producing after queued messages are sent.
+func (l *Logger) WithKafkaOutput(producer KafkaProducer, topic string) (*Logger, error) {
+	aw := NewAsyncWriter(kafkaWriter{producer: producer, topic: topic}, kafkaQueueSize)
+	aw.dropOldest = true
+	aw.metrics = l.metrics
+
+	return l.withOutput(aw, aw.Close)
+}
+
+// kafkaWriter produces written data as messages with level name as key.
+type kafkaWriter struct {
+	producer KafkaProducer
+	topic    string
+}
+
+func (w kafkaWriter) Write(p []byte) (int, error) {
+	return len(p), w.WriteLevel(zapcore.InfoLevel, p)
+}
+
+func (w kafkaWriter) WriteLevel(level zapcore.Level, p []byte) error {
+	return w.producer.Produce(w.topic, []byte(level.String()), p)
+}
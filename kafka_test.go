@@ -0,0 +1,54 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+type kafkaProducerMock struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (p *kafkaProducerMock) Produce(topic string, key, value []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, topic+" "+string(key)+" "+string(value))
+
+	return nil
+}
+
+func TestLogger_WithKafkaOutput(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	p := &kafkaProducerMock{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	kc, err := c.WithKafkaOutput(p, "logs")
+	require.NoError(t, err)
+
+	kc.Info(context.Background(), "hello", "foo", 1)
+	kc.Error(context.Background(), "failed")
+	require.NoError(t, kc.ZapLogger().Sync())
+
+	p.mu.Lock()
+	assert.Equal(t, []string{
+		`logs info {"level":"info","time":"<stripped>","msg":"hello","foo":1}` + "\n",
+		`logs error {"level":"error","time":"<stripped>","msg":"failed"}` + "\n",
+	}, p.messages)
+	p.mu.Unlock()
+
+	require.NoError(t, kc.Close())
+	assert.Equal(t, "", w.String())
+}
@@ -0,0 +1,6 @@
+//go:build zapctxd_no_kv_check
+
+package zapctxd
+
+// kvCheck enables check of odd number of key-value pairs, it is disabled with zapctxd_no_kv_check build tag.
+const kvCheck = false
@@ -0,0 +1,50 @@
+//go:build !zapctxd_no_kv_check
+
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_oddKeysAndValues(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	_, file, line, _ := runtime.Caller(0)
+
+	c.Info(context.Background(), "hello", "foo", "bar", "baz")
+	c.Info(context.Background(), "fields", zap.Int("a", 1), "foo", "bar", zapctxd.Priority("b", 2, 1))
+
+	assert.Equal(t, fmt.Sprintf(`{"level":"warn","time":"<stripped>","msg":"odd number of key-value pairs in log entry","caller":"%s:%d","entry_msg":"hello","count":3}
+{"level":"error","time":"<stripped>","msg":"Ignored key without a value.","ignored":"baz"}
+{"level":"info","time":"<stripped>","msg":"hello","foo":"bar"}
+{"level":"info","time":"<stripped>","msg":"fields","a":1,"foo":"bar","b":2}
+`, file, line+2), w.String())
+}
+
+func TestLogger_oddKeysAndValues_dev(t *testing.T) {
+	c := zapctxd.New(zapctxd.Config{
+		DevMode: true,
+		Output:  bytes.NewBuffer(nil),
+	})
+
+	_, file, line, _ := runtime.Caller(0)
+
+	assert.PanicsWithValue(t, fmt.Sprintf(`odd number of key-value pairs in log entry "hello" at %s:%d: 1 elements`, file, line+4),
+		func() {
+			c.Info(context.Background(), "hello", "foo")
+		})
+}
@@ -0,0 +1,93 @@
+package zapctxd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type levelEnablerBox struct {
+	zapcore.LevelEnabler
+}
+
+// sharedLevel is a level enabler shared by reference between logger and its derived loggers.
+type sharedLevel struct {
+	v atomic.Value
+}
+
+func newSharedLevel(le zapcore.LevelEnabler) *sharedLevel {
+	s := &sharedLevel{}
+	s.set(le)
+
+	return s
+}
+
+func (s *sharedLevel) get() zapcore.LevelEnabler { //nolint:ireturn
+	return s.v.Load().(levelEnablerBox).LevelEnabler //nolint:errcheck // Type is controlled.
+}
+
+func (s *sharedLevel) set(le zapcore.LevelEnabler) {
+	s.v.Store(levelEnablerBox{LevelEnabler: le})
+}
+
+// Enabled implements zapcore.LevelEnabler.
+func (s *sharedLevel) Enabled(level zapcore.Level) bool {
+	return s.get().Enabled(level)
+}
+
+type levelCtxKey struct{}
+
+// WithLevel returns a context that raises minimal level of entries logged with it.
+//
+// Entries below level are dropped even if logger level allows them, unless context is marked with ctxd.WithDebug.
+// Level lower than logger level has no effect.
+func WithLevel(ctx context.Context, level zapcore.Level) context.Context {
+	return context.WithValue(ctx, levelCtxKey{}, level)
+}
+
+// ctxLevel returns minimal level of context set with WithLevel.
+func ctxLevel(ctx context.Context) (zapcore.Level, bool) {
+	level, ok := ctx.Value(levelCtxKey{}).(zapcore.Level)
+
+	return level, ok
+}
+
+// WithCustomLevel returns a copy of logger that displays entries of severity level with name.
+//
+// Escape sequence ansiColor, e.g. "\x1b[35m", is used for name in development mode with Config.ColoredOutput.
+// Logger created with zap loggers is returned unchanged.
+func (l *Logger) WithCustomLevel(name string, severity zapcore.Level, ansiColor string) *Logger {
+	if l.mu == nil {
+		return l
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.format == encodingConsole && l.cfg.ColoredOutput && ansiColor != "" {
+		name = ansiColor + name + "\x1b[0m"
+	}
+
+	nl := *l
+
+	nl.mu = &sync.RWMutex{}
+	nl.encoderOptions = append(l.encoderOptions[:len(l.encoderOptions):len(l.encoderOptions)], func(ec *zapcore.EncoderConfig) {
+		encodeLevel := ec.EncodeLevel
+
+		ec.EncodeLevel = func(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+			if level == severity {
+				enc.AppendString(name)
+
+				return
+			}
+
+			encodeLevel(level, enc)
+		}
+	})
+	nl.encoder = newEncoder(l.cfg, l.format, nl.encoderOptions...)
+	nl.make()
+
+	return &nl
+}
@@ -0,0 +1,68 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestWithLevel(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	ctx := zapctxd.WithLevel(context.Background(), zap.ErrorLevel)
+
+	c.Info(ctx, "noisy")
+	c.Warn(ctx, "noisy warning")
+	c.Error(ctx, "failed")
+	c.Info(context.Background(), "other request")
+	c.Info(zapctxd.WithLevel(context.Background(), zap.DebugLevel), "lower level")
+	c.Debug(zapctxd.WithLevel(context.Background(), zap.DebugLevel), "hidden")
+	c.Debug(ctxd.WithDebug(ctx), "forced debug")
+
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"failed"}
+{"level":"info","time":"<stripped>","msg":"other request"}
+{"level":"info","time":"<stripped>","msg":"lower level"}
+{"level":"debug","time":"<stripped>","msg":"forced debug"}
+`, w.String())
+}
+
+func TestLogger_WithCustomLevel(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithCustomLevel("AUDIT", zap.InfoLevel, "\x1b[35m")
+
+	c.Info(context.Background(), "user logged in")
+	c.Warn(context.Background(), "warning")
+
+	assert.Equal(t, `{"level":"AUDIT","time":"<stripped>","msg":"user logged in"}
+{"level":"warn","time":"<stripped>","msg":"warning"}
+`, w.String())
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		Level:         zap.DebugLevel,
+		StripTime:     true,
+		DevMode:       true,
+		ColoredOutput: true,
+		Output:        w,
+	}).WithCustomLevel("TRACE", zap.DebugLevel, "\x1b[90m")
+
+	c.Debug(context.Background(), "hello")
+
+	assert.Contains(t, w.String(), "<stripped>\t\x1b[90mTRACE\x1b[0m\t")
+}
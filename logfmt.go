@@ -0,0 +1,130 @@
+package zapctxd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var logfmtPool = buffer.NewPool()
+
+// logfmtEncoder encodes entries in logfmt format by transforming output of JSON encoder.
+type logfmtEncoder struct {
+	zapcore.Encoder
+}
+
+func newLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return logfmtEncoder{Encoder: zapcore.NewJSONEncoder(cfg)}
+}
+
+func (e logfmtEncoder) Clone() zapcore.Encoder {
+	return logfmtEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	jb, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	defer jb.Free()
+
+	buf := logfmtPool.Get()
+
+	if err := appendLogfmt(buf, "", bytes.TrimSpace(jb.Bytes())); err != nil {
+		buf.Free()
+
+		return nil, err
+	}
+
+	buf.AppendByte('\n')
+
+	return buf, nil
+}
+
+// appendLogfmt writes JSON object as logfmt pairs, nested object keys are prefixed.
+func appendLogfmt(buf *buffer.Buffer, prefix string, obj []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(obj))
+	dec.UseNumber()
+
+	// Opening brace.
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key := prefix + t.(string) //nolint:errcheck // JSON object keys are strings.
+
+		var raw json.RawMessage
+
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+
+		if len(raw) > 0 && raw[0] == '{' {
+			if err := appendLogfmt(buf, key+".", raw); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if buf.Len() > 0 {
+			buf.AppendByte(' ')
+		}
+
+		buf.AppendString(logfmtKey(key))
+		buf.AppendByte('=')
+
+		if len(raw) > 0 && raw[0] == '"' {
+			var s string
+
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return err
+			}
+
+			buf.AppendString(logfmtValue(s))
+
+			continue
+		}
+
+		// Numbers, booleans, null and arrays as JSON, quoted if necessary.
+		buf.AppendString(logfmtValue(string(raw)))
+	}
+
+	return nil
+}
+
+func logfmtKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '=' || r == '"' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return '_'
+		}
+
+		return r
+	}, k)
+}
+
+func logfmtValue(v string) string {
+	if v == "" {
+		return `""`
+	}
+
+	for _, r := range v {
+		if r == '=' || r == '"' || r == '\\' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return strconv.Quote(v)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,56 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+type httpInfo struct{}
+
+func (httpInfo) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("method", "GET")
+	enc.AddInt("status", 200)
+
+	return nil
+}
+
+func TestLogger_WithLogfmtEncoder(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "user", "John Doe")
+
+	c.WithLogfmtEncoder().Info(ctx, "hello world",
+		"http", httpInfo{}, "tags", []string{"a", "b"}, "empty", "", "ok", true)
+	c.Info(ctx, "json is not affected")
+
+	assert.Equal(t, `level=info time=<stripped> msg="hello world" http.method=GET http.status=200 tags="[\"a\",\"b\"]" empty="" ok=true user="John Doe"
+{"level":"info","time":"<stripped>","msg":"json is not affected","user":"John Doe"}
+`, w.String())
+}
+
+func TestConfig_Encoding(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+		Encoding:  "logfmt",
+	}).WithNodeID("n1")
+
+	c.Warn(context.Background(), "hello", "quote", `a "b"`)
+
+	assert.Equal(t, `level=warn time=<stripped> msg=hello node_id=n1 quote="a \"b\""
+`, w.String())
+}
@@ -3,12 +3,16 @@ package zapctxd
 
 import (
 	"context"
+	"crypto/cipher"
 	"errors"
 	"io"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bool64/ctxd"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -21,26 +25,171 @@ type Logger struct {
 	AtomicLevel zap.AtomicLevel
 
 	callerSkip   bool
+	caller       bool
+	callerFrames int
+	stacktrace   bool
+	stackLevel   zapcore.Level
 	encoder      zapcore.Encoder
-	levelEnabler zapcore.LevelEnabler
+	levelEnabler *sharedLevel
 	sugared      *zap.SugaredLogger
 	debug        *zap.SugaredLogger
+	zl           *zap.Logger
+	zdebug       *zap.Logger
 	options      []zap.Option
 	out          zapcore.WriteSyncer
+
+	mu             *sync.RWMutex
+	cfg            Config
+	devMode        bool
+	fieldNames     FieldNames
+	fields         []zap.Field
+	name           string
+	encoderOptions []func(ec *zapcore.EncoderConfig)
+	format         string
+	idempotencyTTL time.Duration
+	required       []string
+	ctxTimeout     time.Duration
+	fieldCache     *fieldCache
+	strict         bool
+	seq            *atomic.Uint64
+	schemaEnabled  *atomic.Bool
+	debugFlag      func(ctx context.Context) bool
+	closers        []func() error
+	syncers        []func() error
+	deferred       *deferredWriter
+	metrics        *metrics
+	shards         *shards
+	asyncQueue     *asyncQueue
+	scoped         []any
+
+	firstError *onceHook
+	processors []func(e entry) []any
+	sanitizers []func(key string, value any) (any, bool)
+	filters    []func(e entry) bool
+	hooks      []func(e entry)
+}
+
+// FieldNames defines field names in addition to standard ctxd.FieldNames.
+type FieldNames struct {
+	ctxd.FieldNames
+
+	// NodeID is a field name for Logger.WithNodeID, default "node_id".
+	NodeID string `split_words:"true"`
+	// Environment is a field name for Logger.WithEnvironment and Config.Environment, default "env".
+	Environment string
+	// Service is a field name for Config.ServiceName, default "service".
+	Service string
+	// Version is a field name for Config.ServiceVersion, default "version".
+	Version string
+	// Hostname is a field name for Config.IncludeHostname, default "hostname".
+	Hostname string
+	// PID is a field name for Config.IncludePID, default "pid".
+	PID string
+	// Logger is a field name for logger name of Logger.Named, default "logger".
+	Logger string
+	// LogID is a field name for Logger.WithIDField, default "log_id".
+	LogID string `split_words:"true"`
 }
 
 // Config is log configuration.
 type Config struct {
-	Level      zapcore.Level   `split_words:"true" default:"error"`
-	DevMode    bool            `split_words:"true"`
-	FieldNames ctxd.FieldNames `split_words:"true"`
+	Level      zapcore.Level `split_words:"true" default:"error"`
+	DevMode    bool          `split_words:"true"`
+	FieldNames FieldNames    `split_words:"true"`
 	Output     io.Writer
 	ZapOptions []zap.Option
 
+	// Outputs are additional outputs with level filters, entries of all levels are written to Output if it is set.
+	Outputs []OutputSpec
+
+	// Encoding is an output format: "json", "console" or "logfmt".
+	// Default is "console" in development mode and "json" otherwise, unknown values fall back to "json".
+	Encoding string
+
 	// ColoredOutput enables colored output in development mode.
 	ColoredOutput bool
 	// StripTime disables time variance in logger.
 	StripTime bool
+
+	// GoroutineID adds "goroutine_id" field with an identifier of goroutine that emits entry, see Logger.WithGoroutineID.
+	GoroutineID bool `split_words:"true"`
+
+	// AddSequenceNumber adds "seq" field with a monotonic number of entry in logger instance.
+	// Sequence starts again with Logger.Reset or Logger.Clone.
+	AddSequenceNumber bool
+
+	// AnnotationPrefix is a key prefix for Logger.WithAnnotation, default "@".
+	AnnotationPrefix string `split_words:"true"`
+
+	// RedactFields maps keys of sensitive fields to functions that replace their values, e.g. RedactMask("***").
+	RedactFields map[string]RedactFunc
+
+	// EncryptedFields maps keys of sensitive string fields to ciphers to encrypt their values.
+	EncryptedFields map[string]cipher.AEAD
+	// FieldEncrypter allows custom key management for encrypted fields, it overrides EncryptedFields.
+	FieldEncrypter FieldEncrypter
+
+	// FeatureFlagTTL is a time to cache flag evaluation in Logger.WithOpenFeature, default 1s.
+	FeatureFlagTTL time.Duration `split_words:"true"`
+
+	// FlushInterval is a period to flush compressed output of Logger.WithCompression, default 1s.
+	FlushInterval time.Duration `split_words:"true"`
+
+	// AuditHMACKey is a key to sign audit records of Logger.WithAuditTrail with HMAC-SHA256.
+	AuditHMACKey []byte
+
+	// ServiceName, ServiceVersion and Environment are added to every entry before other fields when not empty.
+	// Environment does not adjust logger behavior, unlike Logger.WithEnvironment.
+	ServiceName    string `split_words:"true"`
+	ServiceVersion string `split_words:"true"`
+	Environment    string
+
+	// ErrorStackTrace adds "stacktrace" field with stack trace of call site to entries of Error level and above.
+	// Stack trace is only captured for entries that are written.
+	ErrorStackTrace bool `split_words:"true"`
+
+	// StackTraceMinLevel extends Config.ErrorStackTrace to lower levels, e.g. zap.WarnLevel, default Error.
+	StackTraceMinLevel zapcore.Level `split_words:"true"`
+
+	// DeduplicateFields drops context fields with keys of call-site fields.
+	DeduplicateFields bool `split_words:"true"`
+
+	// ContextFieldPriority makes Config.DeduplicateFields drop call-site fields with keys of context fields instead.
+	ContextFieldPriority bool `split_words:"true"`
+
+	// DefaultContextPriority is a priority of context fields for Priority, default 50.
+	DefaultContextPriority int `split_words:"true"`
+
+	// IncludeHostname adds host name to every entry, error of getting host name is used as value.
+	IncludeHostname bool `split_words:"true"`
+
+	// IncludePID adds process ID to every entry.
+	IncludePID bool `split_words:"true"`
+
+	// EnvironmentBehaviors overrides DefaultEnvironmentBehaviors for Logger.WithEnvironment.
+	EnvironmentBehaviors map[string]EnvironmentBehavior
+
+	// IdempotencyTTL is a time to suppress repeated entries in Logger.WithIdempotencyKey, default 1m.
+	IdempotencyTTL time.Duration
+
+	// LokiDynamicLabels are keys of fields promoted to stream labels in Logger.WithLoki.
+	LokiDynamicLabels []string `split_words:"true"`
+
+	// AsyncBuffer is a number of entries buffered for asynchronous writing to output, 0 disables buffering.
+	// When buffer is full the oldest entry is dropped and counted in LoggerMetrics.DroppedEntries.
+	// Logger.Sync waits for buffered entries to be written, Logger.Close stops buffering.
+	// Buffering takes stalls of output off logging calls, but it costs a copy of each entry and needs
+	// a spare CPU for background writes, so it does not speed up fast outputs.
+	AsyncBuffer int `split_words:"true"`
+
+	// RateLimit limits rate of entries with the same level and message, nil disables rate limiting.
+	RateLimit *RateLimitConfig
+
+	// Sampling limits repeated entries with the same level and message, nil disables sampling.
+	Sampling *SamplingConfig
+
+	// Hooks are called after entries are written to output.
+	Hooks []Hook
 }
 
 // New creates contextualized logger with zap backend.
@@ -57,88 +206,215 @@ func New(cfg Config, options ...zap.Option) *Logger {
 		out = zapcore.AddSync(cfg.Output)
 	}
 
-	encoderConfig := zap.NewProductionEncoderConfig()
-	timeEncoder := zapcore.ISO8601TimeEncoder
+	if len(cfg.Outputs) > 0 {
+		out = newMultiOutput(cfg.Output, cfg.Outputs)
+	}
 
-	if cfg.StripTime {
-		timeEncoder = func(_ time.Time, enc zapcore.PrimitiveArrayEncoder) {
-			enc.AppendString("<stripped>")
-		}
+	m := &metrics{}
+
+	var closers []func() error
+
+	if cfg.AsyncBuffer > 0 {
+		// Output is wrapped to hide io.Closer, it is not owned by logger.
+		aw := NewAsyncWriter(unownedOutput{out}, cfg.AsyncBuffer)
+		aw.dropOldest = true
+		aw.metrics = m
+
+		out = aw
+		closers = append(closers, aw.Close)
 	}
 
 	l := Logger{
-		levelEnabler: zap.NewAtomicLevelAt(level),
+		levelEnabler: newSharedLevel(zap.NewAtomicLevelAt(level)),
 		out:          out,
-		options:      append(cfg.ZapOptions, options...),
+		options:      append(cfg.ZapOptions[:len(cfg.ZapOptions):len(cfg.ZapOptions)], options...),
+
+		mu:             &sync.RWMutex{},
+		cfg:            cfg,
+		metrics:        m,
+		closers:        closers,
+		asyncQueue:     &asyncQueue{},
+		fieldNames:     cfg.FieldNames,
+		idempotencyTTL: cfg.IdempotencyTTL,
 	}
 
-	if cfg.DevMode {
-		encoderConfig = zap.NewDevelopmentEncoderConfig()
-		encoderConfig.EncodeTime = timeEncoder
+	l.stackLevel, l.stacktrace = stacktraceLevel(l.options)
 
-		if cfg.ColoredOutput {
-			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	if cfg.RateLimit != nil && cfg.RateLimit.MessagesPerSecond > 0 {
+		rl := l.rateLimiter(*cfg.RateLimit)
+		l.filters = append(l.filters, rl.filter)
+		l.syncers = append(l.syncers, rl.flush)
+		// Dropped entries are reported before output is closed.
+		l.closers = append([]func() error{rl.flush}, l.closers...)
+	}
+
+	if len(cfg.RedactFields) > 0 {
+		l.sanitizers = append(l.sanitizers, redactValue(cfg.RedactFields))
+	}
+
+	if cfg.FieldEncrypter == nil && len(cfg.EncryptedFields) > 0 {
+		cfg.FieldEncrypter = AEADEncrypter{Ciphers: cfg.EncryptedFields}
+	}
+
+	if cfg.FieldEncrypter != nil {
+		l.sanitizers = append(l.sanitizers, encryptValue(cfg.FieldEncrypter))
+	}
+
+	for _, s := range l.sanitizers {
+		l.processors = append(l.processors, sanitizeKV(s))
+	}
+
+	if fields := l.sanitize(serviceFields(cfg)); len(fields) > 0 {
+		l.options = append([]zap.Option{zap.Fields(fields...)}, l.options...)
+	}
+
+	if cfg.GoroutineID {
+		l.processors = append(l.processors, addGoroutineID)
+	}
+
+	if cfg.AddSequenceNumber {
+		l.seq = new(atomic.Uint64)
+	}
+
+	format := cfg.Encoding
+	if format == "" {
+		format = encodingJSON
+
+		if cfg.DevMode {
+			format = encodingConsole
 		}
+	}
 
-		l.encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	l.format = format
+	l.encoder = newEncoder(cfg, format)
+
+	if cfg.DevMode {
 		l.callerSkip = true
+		l.caller = true
+		l.devMode = true
 		l.options = append(l.options, zap.Development(), zap.AddCaller(), zap.AddCallerSkip(1))
-	} else {
-		encoderConfig.MessageKey = "msg"
-		encoderConfig.TimeKey = "time"
+	}
 
-		if cfg.FieldNames.Message != "" {
-			encoderConfig.MessageKey = cfg.FieldNames.Message
+	if cfg.ErrorStackTrace {
+		stackLevel := zap.ErrorLevel
+		if cfg.StackTraceMinLevel != 0 {
+			stackLevel = cfg.StackTraceMinLevel
 		}
 
-		if cfg.FieldNames.Timestamp != "" {
-			encoderConfig.TimeKey = cfg.FieldNames.Timestamp
+		// Stack trace starts after skipped frames, so they must include the frame of Logger.
+		if !l.callerSkip {
+			l.callerSkip = true
+			l.options = append(l.options, zap.AddCallerSkip(1))
 		}
 
-		encoderConfig.EncodeTime = timeEncoder
-		l.encoder = zapcore.NewJSONEncoder(encoderConfig)
+		l.options = append(l.options, zap.AddStacktrace(stackLevel))
+		l.stacktrace = true
+		l.stackLevel = stackLevel
 	}
 
 	l.make()
 
+	for _, h := range cfg.Hooks {
+		l.hooks = append(l.hooks, l.fireHook(h))
+	}
+
 	return &l
 }
 
+func newEncoder(cfg Config, format string, options ...func(ec *zapcore.EncoderConfig)) zapcore.Encoder {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	timeEncoder := zapcore.ISO8601TimeEncoder
+
+	if cfg.StripTime {
+		timeEncoder = func(_ time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString("<stripped>")
+		}
+	}
+
+	if format == encodingConsole {
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeTime = timeEncoder
+
+		if cfg.ColoredOutput {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+
+		for _, o := range options {
+			o(&encoderConfig)
+		}
+
+		return zapcore.NewConsoleEncoder(encoderConfig)
+	}
+
+	encoderConfig.MessageKey = "msg"
+	encoderConfig.TimeKey = "time"
+
+	if cfg.FieldNames.Message != "" {
+		encoderConfig.MessageKey = cfg.FieldNames.Message
+	}
+
+	if cfg.FieldNames.Timestamp != "" {
+		encoderConfig.TimeKey = cfg.FieldNames.Timestamp
+	}
+
+	if cfg.FieldNames.Logger != "" {
+		encoderConfig.NameKey = cfg.FieldNames.Logger
+	}
+
+	encoderConfig.EncodeTime = timeEncoder
+
+	for _, o := range options {
+		o(&encoderConfig)
+	}
+
+	if format == encodingLogfmt {
+		return newLogfmtEncoder(encoderConfig)
+	}
+
+	return zapcore.NewJSONEncoder(encoderConfig)
+}
+
 // WrapZapLoggers creates contextualized logger with provided zap loggers.
 func WrapZapLoggers(sugared, debug *zap.Logger, encoder zapcore.Encoder, options ...zap.Option) *Logger {
 	sugared = sugared.WithOptions(options...)
 	debug = debug.WithOptions(options...)
 
 	return &Logger{
-		levelEnabler: sugared.Core(),
+		levelEnabler: newSharedLevel(sugared.Core()),
 		sugared:      sugared.Sugar(),
 		debug:        debug.Sugar(),
+		zl:           sugared,
+		zdebug:       debug,
 		encoder:      encoder,
 		options:      options,
+		metrics:      &metrics{},
+		asyncQueue:   &asyncQueue{},
 	}
 }
 
 func (l *Logger) make() {
-	l.sugared = zap.New(zapcore.NewCore(
-		l.encoder,
-		l.out,
-		loggerLevelEnabler(l),
-	), l.options...).Sugar()
+	core := l.newShardedCore(l.levelEnabler)
 
-	l.debug = zap.New(zapcore.NewCore(
-		l.encoder,
-		l.out,
-		zap.DebugLevel,
-	), l.options...).Sugar()
+	// Zero sampling config would drop all entries, it is ignored.
+	if s := l.cfg.Sampling; s != nil && (s.Initial > 0 || s.Thereafter > 0) {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, s.Initial, s.Thereafter)
+	}
+
+	l.zl = zap.New(core, l.options...).Named(l.name).With(l.fields...)
+
+	l.zdebug = zap.New(l.newShardedCore(zap.DebugLevel), l.options...).Named(l.name).With(l.fields...)
+
+	l.sugared = l.zl.Sugar()
+	l.debug = l.zdebug.Sugar()
 }
 
-// SetLevelEnabler sets level enabler.
+// SetLevelEnabler sets level enabler, it affects derived loggers too.
 func (l *Logger) SetLevelEnabler(enabler zapcore.LevelEnabler) {
-	if _, ok := l.levelEnabler.(zapcore.Core); ok {
+	if _, ok := l.levelEnabler.get().(zapcore.Core); ok {
 		panic("cannot set level enabler when logger is created with zap loggers")
 	}
 
-	l.levelEnabler = enabler
+	l.levelEnabler.set(enabler)
 }
 
 // SkipCaller adapts logger for wrapping by increasing skip caller counter.
@@ -149,31 +425,72 @@ func (l *Logger) SkipCaller() *Logger {
 
 	nl := *l
 
-	nl.debug = nl.debug.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
-	nl.sugared = nl.sugared.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
+	nl.callerFrames++
+	nl.options = append(l.options[:len(l.options):len(l.options)], zap.AddCallerSkip(1))
+	nl.zl = l.zl.WithOptions(zap.AddCallerSkip(1))
+	nl.zdebug = l.zdebug.WithOptions(zap.AddCallerSkip(1))
+	nl.sugared = nl.zl.Sugar()
+	nl.debug = nl.zdebug.Sugar()
 
 	return &nl
 }
 
 // Debug implements ctxd.Logger.
 func (l *Logger) Debug(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.strict {
+		l.checkStrict(ctx, msg, keysAndValues)
+	}
+
 	z := l.get(ctx, zap.DebugLevel)
 	if z == nil {
 		return
 	}
 
+	e := entry{ctx: ctx, level: zap.DebugLevel, msg: msg}
+	kv := l.prepareKV(e, keysAndValues)
+
+	if l.skip(e, kv) {
+		return
+	}
+
+	z.Debugw(msg, kv...)
+	l.written(e, kv)
+}
+
+func (l *Logger) prepareKV(e entry, keysAndValues []any) []any {
+	if kvCheck && len(keysAndValues)%2 != 0 {
+		l.checkOddKV(e.msg, keysAndValues)
+	}
+
+	if len(l.scoped) > 0 {
+		keysAndValues = append(l.scoped[:len(l.scoped):len(l.scoped)], keysAndValues...)
+	}
+
 	var (
-		fv = ctxd.Fields(ctx)
-		kv = keysAndValues
+		fv, timedOut = l.contextFields(e.ctx)
+		kv           = keysAndValues
 	)
 
-	if len(fv) > 0 {
+	if timedOut {
+		kv = append(kv[:len(kv):len(kv)], "ctx_timeout", true)
+	}
+
+	switch {
+	case len(fv) > 0 && l.cfg.DeduplicateFields:
+		kv = l.mergeFields(keysAndValues, fv)
+	case len(fv) > 0:
 		kv = make([]any, 0, len(fv)+len(kv))
 
 		kv = append(kv, keysAndValues...)
 		kv = append(kv, fv...)
 	}
 
+	if hasPriority(kv) {
+		kv = l.resolvePriority(kv, len(keysAndValues))
+	}
+
+	kv = l.normalizeKeys(e.msg, kv)
+
 	for i := 1; i < len(kv); i += 2 {
 		v := kv[i]
 		if err, ok := v.(error); ok {
@@ -187,7 +504,11 @@ func (l *Logger) Debug(ctx context.Context, msg string, keysAndValues ...any) {
 		}
 	}
 
-	z.Debugw(msg, kv...)
+	if len(l.processors) > 0 || l.seq != nil {
+		kv = l.process(e, kv)
+	}
+
+	return kv
 }
 
 func expandError(kv []any, se ctxd.StructuredError, i int) []any {
@@ -216,179 +537,240 @@ func expandError(kv []any, se ctxd.StructuredError, i int) []any {
 
 // Info implements ctxd.Logger.
 func (l *Logger) Info(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.strict {
+		l.checkStrict(ctx, msg, keysAndValues)
+	}
+
 	z := l.get(ctx, zap.InfoLevel)
 	if z == nil {
 		return
 	}
 
-	var (
-		fv = ctxd.Fields(ctx)
-		kv = keysAndValues
-	)
-
-	if len(fv) > 0 {
-		kv = make([]any, 0, len(fv)+len(kv))
+	e := entry{ctx: ctx, level: zap.InfoLevel, msg: msg}
+	kv := l.prepareKV(e, keysAndValues)
 
-		kv = append(kv, keysAndValues...)
-		kv = append(kv, fv...)
-	}
-
-	for i := 1; i < len(kv); i += 2 {
-		v := kv[i]
-		if err, ok := v.(error); ok {
-			kv[i] = err.Error()
-
-			var se ctxd.StructuredError
-
-			if errors.As(err, &se) {
-				kv = expandError(kv, se, i)
-			}
-		}
+	if l.skip(e, kv) {
+		return
 	}
 
 	z.Infow(msg, kv...)
+	l.written(e, kv)
 }
 
 // Important implements ctxd.Logger.
 func (l *Logger) Important(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.strict {
+		l.checkStrict(ctx, msg, keysAndValues)
+	}
+
 	z := l.get(ctxd.WithDebug(ctx), zap.InfoLevel)
 	if z == nil {
 		return
 	}
 
-	var (
-		fv = ctxd.Fields(ctx)
-		kv = keysAndValues
-	)
+	e := entry{ctx: ctx, level: zap.InfoLevel, important: true, msg: msg}
+	kv := l.prepareKV(e, keysAndValues)
 
-	if len(fv) > 0 {
-		kv = make([]any, 0, len(fv)+len(kv))
+	if l.skip(e, kv) {
+		return
+	}
 
-		kv = append(kv, keysAndValues...)
-		kv = append(kv, fv...)
+	z.Infow(msg, kv...)
+	l.written(e, kv)
+}
+
+// Warn implements ctxd.Logger.
+func (l *Logger) Warn(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.strict {
+		l.checkStrict(ctx, msg, keysAndValues)
 	}
 
-	for i := 1; i < len(kv); i += 2 {
-		v := kv[i]
-		if err, ok := v.(error); ok {
-			kv[i] = err.Error()
+	z := l.get(ctx, zap.WarnLevel)
+	if z == nil {
+		return
+	}
 
-			var se ctxd.StructuredError
+	e := entry{ctx: ctx, level: zap.WarnLevel, msg: msg}
+	kv := l.prepareKV(e, keysAndValues)
 
-			if errors.As(err, &se) {
-				kv = expandError(kv, se, i)
-			}
-		}
+	if l.skip(e, kv) {
+		return
 	}
 
-	z.Infow(msg, kv...)
+	z.Warnw(msg, kv...)
+	l.written(e, kv)
 }
 
-// Warn implements ctxd.Logger.
-func (l *Logger) Warn(ctx context.Context, msg string, keysAndValues ...any) {
-	z := l.get(ctx, zap.WarnLevel)
+// Error implements ctxd.Logger.
+func (l *Logger) Error(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.strict {
+		l.checkStrict(ctx, msg, keysAndValues)
+	}
+
+	z := l.get(ctx, zap.ErrorLevel)
 	if z == nil {
 		return
 	}
 
-	var (
-		fv = ctxd.Fields(ctx)
-		kv = keysAndValues
-	)
+	e := entry{ctx: ctx, level: zap.ErrorLevel, msg: msg}
+	kv := l.prepareKV(e, keysAndValues)
 
-	if len(fv) > 0 {
-		kv = make([]any, 0, len(fv)+len(kv))
+	if l.skip(e, kv) {
+		return
+	}
 
-		kv = append(kv, keysAndValues...)
-		kv = append(kv, fv...)
+	z.Errorw(msg, kv...)
+	l.written(e, kv)
+}
+
+// Fatal logs a message at fatal level and then calls os.Exit(1).
+//
+// Same as Error, it uses fields of context and respects ctxd.LogWriter, exit can be
+// intercepted with zap.WithFatalHook in Config.ZapOptions. Exit happens even if entry is dropped by filters.
+func (l *Logger) Fatal(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.strict {
+		l.checkStrict(ctx, msg, keysAndValues)
 	}
 
-	for i := 1; i < len(kv); i += 2 {
-		v := kv[i]
-		if err, ok := v.(error); ok {
-			kv[i] = err.Error()
+	z := l.get(ctx, zap.FatalLevel)
+	if z == nil {
+		l.terminate(zap.FatalLevel, msg)
 
-			var se ctxd.StructuredError
+		return
+	}
 
-			if errors.As(err, &se) {
-				kv = expandError(kv, se, i)
-			}
-		}
+	e := entry{ctx: ctx, level: zap.FatalLevel, msg: msg}
+	kv := l.prepareKV(e, keysAndValues)
+
+	if l.skip(e, kv) {
+		l.terminate(zap.FatalLevel, msg)
+
+		return
 	}
 
-	z.Warnw(msg, kv...)
+	// Hooks are notified before writing, because process exits after that.
+	l.written(e, kv)
+
+	z.Fatalw(msg, kv...)
 }
 
-// Error implements ctxd.Logger.
-func (l *Logger) Error(ctx context.Context, msg string, keysAndValues ...any) {
-	z := l.get(ctx, zap.ErrorLevel)
+// Panic logs a message at panic level and then panics.
+//
+// Same as Error, it uses fields of context and respects ctxd.LogWriter, hooks are
+// notified during panic. Panic happens even if entry is dropped by filters.
+func (l *Logger) Panic(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.strict {
+		l.checkStrict(ctx, msg, keysAndValues)
+	}
+
+	z := l.get(ctx, zap.PanicLevel)
 	if z == nil {
+		l.terminate(zap.PanicLevel, msg)
+
 		return
 	}
 
-	var (
-		fv = ctxd.Fields(ctx)
-		kv = keysAndValues
-	)
+	e := entry{ctx: ctx, level: zap.PanicLevel, msg: msg}
+	kv := l.prepareKV(e, keysAndValues)
 
-	if len(fv) > 0 {
-		kv = make([]any, 0, len(fv)+len(kv))
+	if l.skip(e, kv) {
+		l.terminate(zap.PanicLevel, msg)
 
-		kv = append(kv, keysAndValues...)
-		kv = append(kv, fv...)
+		return
 	}
 
-	for i := 1; i < len(kv); i += 2 {
-		v := kv[i]
-		if err, ok := v.(error); ok {
-			kv[i] = err.Error()
+	defer l.written(e, kv)
 
-			var se ctxd.StructuredError
+	z.Panicw(msg, kv...)
+}
 
-			if errors.As(err, &se) {
-				kv = expandError(kv, se, i)
-			}
-		}
+// terminate exits or panics without writing an entry, as Fatal or Panic of zap logger would do.
+//
+// Filters and level checks may drop entry, but they must not prevent termination.
+func (l *Logger) terminate(level zapcore.Level, msg string) {
+	if l.mu != nil {
+		l.mu.RLock()
 	}
 
-	z.Errorw(msg, kv...)
+	// Terminal behavior, including zap.WithFatalHook, is kept when core is replaced.
+	z := l.zl.WithOptions(zap.WrapCore(func(zapcore.Core) zapcore.Core { return zapcore.NewNopCore() }))
+
+	if l.mu != nil {
+		l.mu.RUnlock()
+	}
+
+	if level == zap.FatalLevel {
+		z.Fatal(msg)
+	}
+
+	z.Panic(msg)
 }
 
 func (l *Logger) get(ctx context.Context, level zapcore.Level) *zap.SugaredLogger {
-	z := l.sugared
-	if !l.levelEnabler.Enabled(level) {
-		z = nil
+	if l.mu != nil {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	isDebug, ok := l.enabled(ctx, level)
+	if !ok {
+		return nil
+	}
+
+	if z := l.writerLogger(ctx, isDebug); z != nil {
+		return z.Sugar()
 	}
 
-	isDebug := ctxd.IsDebug(ctx)
 	if isDebug {
-		z = l.debug
+		return l.debug
 	}
 
-	if z == nil {
-		return nil
+	return l.sugared
+}
+
+// enabled checks if entry of level is enabled and if it should be written with debug logger.
+func (l *Logger) enabled(ctx context.Context, level zapcore.Level) (isDebug bool, ok bool) {
+	enabled := l.levelEnabler.Enabled(level)
+
+	isDebug = ctxd.IsDebug(ctx)
+	if !isDebug {
+		if floor, set := ctxLevel(ctx); set && level < floor {
+			return false, false
+		}
 	}
 
+	if !isDebug && !enabled && l.debugFlag != nil {
+		isDebug = l.debugFlag(ctx)
+	}
+
+	return isDebug, enabled || isDebug
+}
+
+// writerLogger returns logger for writer of context or nil if context has no writer.
+//
+// Entries of writer keep logger name, attached fields and Config.ZapOptions, other options of
+// logger, like caller of DevMode or core wrappers, are not applied.
+func (l *Logger) writerLogger(ctx context.Context, isDebug bool) *zap.Logger {
 	writer := ctxd.LogWriter(ctx)
-	if writer != nil {
-		level := zapcore.LevelEnabler(zap.DebugLevel)
-		if !isDebug {
-			level = l.levelEnabler
-		}
+	if writer == nil {
+		return nil
+	}
 
-		ws, ok := writer.(zapcore.WriteSyncer)
-		if !ok {
-			ws = zapcore.AddSync(writer)
-		}
+	level := zapcore.LevelEnabler(zap.DebugLevel)
+	if !isDebug {
+		level = l.levelEnabler
+	}
 
-		return zap.New(zapcore.NewCore(
-			l.encoder,
-			ws,
-			level,
-		)).Sugar()
+	ws, ok := writer.(zapcore.WriteSyncer)
+	if !ok {
+		ws = zapcore.AddSync(writer)
 	}
 
-	return z
+	return zap.New(zapcore.NewCore(
+		l.encoder,
+		ws,
+		level,
+	), l.cfg.ZapOptions...).Named(l.name).With(l.fields...)
 }
 
 var _ ctxd.LoggerProvider = &Logger{}
@@ -400,11 +782,57 @@ func (l *Logger) CtxdLogger() ctxd.Logger { //nolint: ireturn
 
 // ZapLogger returns *zap.Logger that used in Logger.
 func (l *Logger) ZapLogger() *zap.Logger {
+	if l.mu != nil {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
 	return l.sugared.Desugar()
 }
 
-func loggerLevelEnabler(l *Logger) zap.LevelEnablerFunc {
-	return func(lvl zapcore.Level) bool {
-		return l.levelEnabler.Enabled(lvl)
+// Sync flushes buffered entries of underlying outputs, it should be called before process exit.
+func (l *Logger) Sync() error {
+	var err error
+
+	// Syncers may write pending entries, so they are called first.
+	for _, s := range l.syncers {
+		err = multierr.Append(err, s())
+	}
+
+	// Queued entries are written with a lock of logger, so they are awaited before locking.
+	if l.asyncQueue != nil {
+		l.asyncQueue.sync()
+	}
+
+	if l.mu != nil {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	// Cores of logger and debug logger share output, so it is synced once.
+	if l.mu != nil {
+		return multierr.Append(err, l.zl.Sync())
+	}
+
+	return multierr.Combine(err, l.sugared.Desugar().Sync(), l.debug.Desugar().Sync())
+}
+
+// Clone returns a copy of logger with reset instance state, like sequence number.
+func (l *Logger) Clone() *Logger {
+	nl := *l
+
+	if l.seq != nil {
+		nl.seq = new(atomic.Uint64)
+	}
+
+	return &nl
+}
+
+// Reset resets instance state of logger, like sequence number.
+//
+// State is shared with loggers derived with With and similar methods, but not with Clone.
+func (l *Logger) Reset() {
+	if l.seq != nil {
+		l.seq.Store(0)
 	}
 }
@@ -333,3 +333,48 @@ func TestNew_structuredError(t *testing.T) {
 {"level":"info","time":"<stripped>","msg":"hello!","foo":1,"error":"making foo: failed","ctx":123,"detail1":1,"detail2":2}
 `, w.String(), w.String())
 }
+
+type syncCounter struct {
+	bytes.Buffer
+	syncs int
+	err   error
+}
+
+func (s *syncCounter) Sync() error {
+	s.syncs++
+
+	return s.err
+}
+
+func TestLogger_Sync(t *testing.T) {
+	w := &syncCounter{}
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: w,
+	})
+
+	assert.NoError(t, c.Sync())
+	assert.Equal(t, 1, w.syncs)
+
+	w.err = errors.New("failed")
+
+	err := c.Sync()
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, 2, w.syncs)
+}
+
+func TestLogger_logWriterOptions(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	logger := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		DevMode:   true,
+		Output:    w,
+	})
+	buf := &bytes.Buffer{}
+
+	logger.Named("app").With("foo", 1).Error(ctxd.WithLogWriter(context.Background(), buf), "hello")
+
+	assert.Empty(t, w.String())
+	// Caller and stack trace of DevMode are not applied to writer of context.
+	assert.Equal(t, "<stripped>\tERROR\tapp\thello\t{\"foo\": 1}\n", buf.String())
+}
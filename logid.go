@@ -0,0 +1,42 @@
+package zapctxd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// WithIDField returns a logger that adds unique identifier (random UUID) to every entry.
+//
+// Identifier is only generated for entries that pass level check.
+// Field name can be configured with Config.FieldNames.LogID, default "log_id".
+func (l *Logger) WithIDField() *Logger {
+	key := orDefault(l.fieldNames.LogID, "log_id")
+
+	return l.withProcessor(func(e entry) []any {
+		return append(e.kv, key, newUUID())
+	})
+}
+
+// newUUID returns random UUID version 4.
+func newUUID() string {
+	var u [16]byte
+
+	_, _ = rand.Read(u[:]) //nolint:errcheck // Read of crypto/rand does not fail on supported platforms.
+
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	var b [36]byte
+
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+
+	return string(b[:])
+}
@@ -0,0 +1,50 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithIDField(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+		FieldNames: zapctxd.FieldNames{
+			LogID: "id",
+		},
+	}).WithIDField()
+
+	c.Debug(context.Background(), "skipped")
+	c.Info(context.Background(), "first")
+	c.Info(context.Background(), "second")
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ids := map[string]bool{}
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	require.Len(t, lines, 2)
+
+	for _, line := range lines {
+		var e map[string]any
+
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+
+		id, _ := e["id"].(string)
+		assert.Regexp(t, uuid, id)
+
+		ids[id] = true
+	}
+
+	assert.Len(t, ids, 2)
+}
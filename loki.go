@@ -0,0 +1,231 @@
+package zapctxd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	lokiBatchSize     = 1000
+	lokiFlushInterval = time.Second
+	lokiQueueSize     = 10000
+	lokiTimeout       = 5 * time.Second
+)
+
+// WithLoki returns a clone of logger that pushes written entries to Grafana Loki push API at url,
+// e.g. "http://localhost:3100/loki/api/v1/push".
+//
+// Entries are grouped in streams by static labels and values of fields listed in Config.LokiDynamicLabels.
+// Line of entry is a JSON object with "level", "msg" and merged fields.
+//
+// Entries are sent in batches every second or when batch reaches 1000 entries, failures are
+// logged with original logger. When queue is full the entry is dropped and counted in
+// LoggerMetrics.DroppedEntries.
+// Original logger is not affected, Sync of the clone sends queued entries, Close also stops pushing.
+func (l *Logger) WithLoki(url string, labels map[string]string) *Logger {
+	dynamic := make(map[string]bool, len(l.cfg.LokiDynamicLabels))
+
+	for _, k := range l.cfg.LokiDynamicLabels {
+		dynamic[k] = true
+	}
+
+	p := &lokiPusher{
+		url:    url,
+		labels: labels,
+		client: &http.Client{Timeout: lokiTimeout},
+		logger: l,
+		batch:  make([]lokiEntry, 0, lokiBatchSize),
+		stop:   make(chan struct{}),
+	}
+
+	p.worker = newAsyncWorker(lokiQueueSize, p.add, p.flush)
+	p.worker.metrics = l.metrics
+
+	go p.run(lokiFlushInterval)
+
+	nl := l.withHook(func(e entry) {
+		le := lokiEntry{ts: time.Now()}
+		line := make(map[string]any, 2+len(e.kv)/2)
+
+		for i := 0; i < len(e.kv)-1; i += 2 {
+			k := fmt.Sprint(e.kv[i])
+			line[k] = e.kv[i+1]
+
+			if dynamic[k] {
+				le.labels = append(le.labels, [2]string{k, fmt.Sprint(e.kv[i+1])})
+			}
+		}
+
+		line["level"] = e.level.String()
+		line["msg"] = e.msg
+
+		b, err := json.Marshal(line)
+		if err != nil {
+			l.sugared.Warnw("failed to encode loki entry", "error", err.Error(), "entry_msg", e.msg)
+
+			return
+		}
+
+		le.line = string(b)
+
+		_ = p.worker.enqueue(le) //nolint:errcheck // Entries are not pushed after Close.
+	})
+
+	nl.syncers = append(l.syncers[:len(l.syncers):len(l.syncers)], p.worker.sync)
+	nl.closers = append(l.closers[:len(l.closers):len(l.closers)], p.Close)
+
+	return nl
+}
+
+type lokiEntry struct {
+	ts     time.Time
+	labels [][2]string
+	line   string
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPusher struct {
+	url    string
+	labels map[string]string
+	client *http.Client
+	logger *Logger
+	worker *asyncWorker[lokiEntry]
+	stop   chan struct{}
+
+	// batch is used by a single goroutine of worker.
+	batch []lokiEntry
+}
+
+// run pushes batch every interval.
+func (p *lokiPusher) run(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-t.C:
+			_ = p.worker.sync() //nolint:errcheck // Failures are logged by pusher.
+		}
+	}
+}
+
+func (p *lokiPusher) add(e lokiEntry) {
+	p.batch = append(p.batch, e)
+
+	if len(p.batch) >= lokiBatchSize {
+		_ = p.flush() //nolint:errcheck // Failures are logged.
+	}
+}
+
+func (p *lokiPusher) flush() error {
+	if len(p.batch) == 0 {
+		return nil
+	}
+
+	if err := p.push(p.batch); err != nil {
+		p.logger.sugared.Warnw("failed to push log entries to loki", "error", err.Error(), "count", len(p.batch))
+	}
+
+	p.batch = p.batch[:0]
+
+	return nil
+}
+
+// streams groups entries by labels.
+func (p *lokiPusher) streams(batch []lokiEntry) []*lokiStream {
+	var (
+		streams []*lokiStream
+		byKey   = make(map[string]*lokiStream)
+	)
+
+	for _, e := range batch {
+		labels := make(map[string]string, len(p.labels)+len(e.labels))
+
+		for k, v := range p.labels {
+			labels[k] = v
+		}
+
+		for _, kv := range e.labels {
+			labels[kv[0]] = kv[1]
+		}
+
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for i, k := range keys {
+			keys[i] = k + "=" + labels[k]
+		}
+
+		key := strings.Join(keys, "\x00")
+
+		s, ok := byKey[key]
+		if !ok {
+			s = &lokiStream{Stream: labels}
+			byKey[key] = s
+			streams = append(streams, s)
+		}
+
+		s.Values = append(s.Values, [2]string{strconv.FormatInt(e.ts.UnixNano(), 10), e.line})
+	}
+
+	return streams
+}
+
+func (p *lokiPusher) push(batch []lokiEntry) error {
+	body, err := json.Marshal(struct {
+		Streams []*lokiStream `json:"streams"`
+	}{Streams: p.streams(batch)})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Close sends queued entries and stops pushing.
+func (p *lokiPusher) Close() error {
+	if !p.worker.close() {
+		return nil
+	}
+
+	close(p.stop)
+
+	return p.flush()
+}
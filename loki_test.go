@@ -0,0 +1,99 @@
+package zapctxd_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithLoki(t *testing.T) {
+	type stream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+
+	var pushes [][]stream
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Streams []stream `json:"streams"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		pushes = append(pushes, req.Streams)
+
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := zapctxd.New(zapctxd.Config{
+		Output:            io.Discard,
+		LokiDynamicLabels: []string{"tenant"},
+	}).WithLoki(srv.URL, map[string]string{"app": "test"})
+
+	ctx := ctxd.AddFields(context.Background(), "tenant", "acme")
+
+	c.Info(ctx, "hello", "foo", 1)
+	c.Warn(context.Background(), "no tenant")
+	c.Error(ctx, "failed")
+
+	require.NoError(t, c.Close())
+	require.Len(t, pushes, 1)
+
+	streams := pushes[0]
+	require.Len(t, streams, 2)
+
+	assert.Equal(t, map[string]string{"app": "test", "tenant": "acme"}, streams[0].Stream)
+	require.Len(t, streams[0].Values, 2)
+	assert.Equal(t, `{"foo":1,"level":"info","msg":"hello","tenant":"acme"}`, streams[0].Values[0][1])
+	assert.Equal(t, `{"level":"error","msg":"failed","tenant":"acme"}`, streams[0].Values[1][1])
+
+	assert.Equal(t, map[string]string{"app": "test"}, streams[1].Stream)
+	require.Len(t, streams[1].Values, 1)
+	assert.Equal(t, `{"level":"warn","msg":"no tenant"}`, streams[1].Values[0][1])
+	assert.NotEmpty(t, streams[1].Values[0][0])
+}
+
+func TestLogger_WithLoki_sync(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		pushes int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		pushes++
+
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: io.Discard,
+	}).WithLoki(srv.URL, map[string]string{"app": "test"})
+
+	c.Info(context.Background(), "hello")
+	require.NoError(t, c.Sync())
+
+	mu.Lock()
+	assert.Equal(t, 1, pushes)
+	mu.Unlock()
+
+	require.NoError(t, c.Close())
+
+	// Nothing is left to push on Close.
+	assert.Equal(t, 1, pushes)
+}
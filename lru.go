@@ -0,0 +1,83 @@
+package zapctxd
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lru is a concurrency-safe map of limited size that evicts least recently used items.
+type lru[V any] struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[string]*list.Element
+
+	// evicted is called with evicted item after update, if set.
+	evicted func(key string, v V)
+}
+
+type lruItem[V any] struct {
+	key   string
+	value V
+}
+
+func newLRU[V any](size int) *lru[V] {
+	return &lru[V]{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+// update calls fn with current value (if exists) and stores the result.
+func (c *lru[V]) update(key string, fn func(v V, found bool) V) V {
+	c.mu.Lock()
+
+	if el, ok := c.items[key]; ok {
+		it := el.Value.(*lruItem[V]) //nolint:errcheck // Type is controlled.
+		it.value = fn(it.value, true)
+		c.order.MoveToFront(el)
+		c.mu.Unlock()
+
+		return it.value
+	}
+
+	var v V
+
+	v = fn(v, false)
+	c.items[key] = c.order.PushFront(&lruItem[V]{key: key, value: v})
+
+	var evicted *lruItem[V]
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		evicted = oldest.Value.(*lruItem[V]) //nolint:errcheck // Type is controlled.
+		delete(c.items, evicted.key)
+	}
+
+	c.mu.Unlock()
+
+	if evicted != nil && c.evicted != nil {
+		c.evicted(evicted.key, evicted.value)
+	}
+
+	return v
+}
+
+// reset removes all items and returns them.
+func (c *lru[V]) reset() map[string]V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := make(map[string]V, len(c.items))
+
+	for k, el := range c.items {
+		items[k] = el.Value.(*lruItem[V]).value //nolint:errcheck // Type is controlled.
+	}
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element, c.size)
+
+	return items
+}
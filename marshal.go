@@ -0,0 +1,61 @@
+package zapctxd
+
+import (
+	"encoding/json"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type loggerConfig struct {
+	Level      string           `json:"level"`
+	DevMode    bool             `json:"dev_mode"`
+	Encoder    string           `json:"encoder"`
+	StripTime  bool             `json:"strip_time"`
+	ZapOptions int              `json:"zap_options"`
+	FieldNames loggerFieldNames `json:"field_names"`
+}
+
+type loggerFieldNames struct {
+	Message     string `json:"message"`
+	Timestamp   string `json:"timestamp"`
+	NodeID      string `json:"node_id"`
+	Environment string `json:"environment"`
+}
+
+// MarshalJSON encodes effective configuration of logger.
+//
+// Encoder is "json", "console", "logfmt" or "custom" for logger created with zap loggers.
+func (l *Logger) MarshalJSON() ([]byte, error) {
+	if l.mu != nil {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+
+	c := loggerConfig{
+		Level:      zapcore.LevelOf(l.levelEnabler.get()).String(),
+		DevMode:    l.devMode,
+		Encoder:    l.format,
+		StripTime:  l.cfg.StripTime,
+		ZapOptions: len(l.options),
+		FieldNames: loggerFieldNames{
+			Message:     orDefault(l.fieldNames.Message, "msg"),
+			Timestamp:   orDefault(l.fieldNames.Timestamp, "time"),
+			NodeID:      orDefault(l.fieldNames.NodeID, "node_id"),
+			Environment: orDefault(l.fieldNames.Environment, "env"),
+		},
+	}
+
+	if c.Encoder == "" {
+		c.Encoder = "custom"
+	}
+
+	return json.Marshal(c)
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+
+	return v
+}
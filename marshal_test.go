@@ -0,0 +1,32 @@
+package zapctxd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_MarshalJSON(t *testing.T) {
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.WarnLevel,
+		StripTime: true,
+		FieldNames: zapctxd.FieldNames{
+			FieldNames: ctxd.FieldNames{Message: "message"},
+		},
+	}, zap.AddStacktrace(zap.ErrorLevel))
+
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	assert.Equal(t, `{"level":"warn","dev_mode":false,"encoder":"json","strip_time":true,"zap_options":1,`+
+		`"field_names":{"message":"message","timestamp":"time","node_id":"node_id","environment":"env"}}`, string(b))
+
+	b, err = json.Marshal(c.WithLogfmtEncoder())
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"encoder":"logfmt"`)
+}
@@ -0,0 +1,78 @@
+package zapctxd
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket allows n events per period with bursts up to burst (default n), but at least one event.
+type tokenBucket struct {
+	n      float64
+	period time.Duration
+	burst  float64
+
+	mu      sync.Mutex
+	tokens  float64
+	last    time.Time
+	dropped int64
+}
+
+// take returns true and number of previously dropped events if event is allowed.
+func (b *tokenBucket) take(now time.Time) (bool, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	burst := b.burst
+	if burst == 0 {
+		burst = b.n
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	if b.last.IsZero() {
+		b.tokens = burst
+	} else {
+		b.tokens += b.n * float64(now.Sub(b.last)) / float64(b.period)
+		if b.tokens > burst {
+			b.tokens = burst
+		}
+	}
+
+	b.last = now
+
+	if b.tokens < 1 {
+		b.dropped++
+
+		return false, 0
+	}
+
+	b.tokens--
+
+	dropped := b.dropped
+	b.dropped = 0
+
+	return true, dropped
+}
+
+// WithMaxRate returns a logger that limits total throughput to n entries per period with a token bucket.
+//
+// Entries beyond the limit are dropped regardless of level, number of dropped entries is reported
+// with "log rate limited" warning before the next allowed entry.
+func (l *Logger) WithMaxRate(n int, period time.Duration) *Logger {
+	b := &tokenBucket{n: float64(n), period: period}
+
+	return l.withFilter(func(e entry) bool {
+		ok, dropped := b.take(time.Now())
+		if !ok {
+			return true
+		}
+
+		if dropped > 0 {
+			l.sugared.Warnw("log rate limited", "dropped", dropped)
+		}
+
+		return false
+	})
+}
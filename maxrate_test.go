@@ -0,0 +1,38 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithMaxRate(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	ctx := context.Background()
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithMaxRate(2, 100*time.Millisecond)
+
+	c.Info(ctx, "one")
+	c.Error(ctx, "two")
+	c.Error(ctx, "dropped")
+	c.Info(ctx, "dropped")
+
+	time.Sleep(60 * time.Millisecond)
+
+	c.Info(ctx, "three")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"one"}
+{"level":"error","time":"<stripped>","msg":"two"}
+{"level":"warn","time":"<stripped>","msg":"log rate limited","dropped":2}
+{"level":"info","time":"<stripped>","msg":"three"}
+`, w.String())
+	assert.Equal(t, int64(2), c.Metrics().DroppedEntries)
+}
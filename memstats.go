@@ -0,0 +1,73 @@
+package zapctxd
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type memSnapshot struct {
+	at          time.Time
+	heapAllocMB float64
+	gcCount     uint32
+	gcPauseMS   float64
+}
+
+type memSampler struct {
+	interval time.Duration
+	last     atomic.Pointer[memSnapshot]
+	mu       sync.Mutex
+}
+
+func (s *memSampler) get() *memSnapshot {
+	ms := s.last.Load()
+	if ms != nil && time.Since(ms.at) < s.interval {
+		return ms
+	}
+
+	// Concurrent callers use previous snapshot while it is refreshed.
+	if !s.mu.TryLock() {
+		if ms != nil {
+			return ms
+		}
+
+		s.mu.Lock()
+	}
+
+	defer s.mu.Unlock()
+
+	if ms = s.last.Load(); ms != nil && time.Since(ms.at) < s.interval {
+		return ms
+	}
+
+	var m runtime.MemStats
+
+	runtime.ReadMemStats(&m)
+
+	ms = &memSnapshot{
+		at:          time.Now(),
+		heapAllocMB: float64(m.HeapAlloc) / (1 << 20),
+		gcCount:     m.NumGC,
+		gcPauseMS:   float64(m.PauseNs[(m.NumGC+255)%256]) / float64(time.Millisecond),
+	}
+
+	s.last.Store(ms)
+
+	return ms
+}
+
+// WithMemStats returns a logger that adds "heap_alloc_mb", "gc_count" and "gc_pause_ms" (last GC pause)
+// fields to every entry.
+//
+// Memory stats are sampled lazily at most once per interval and shared by entries within the interval.
+// It is intended for debugging of GC-related performance problems.
+func (l *Logger) WithMemStats(interval time.Duration) *Logger {
+	s := &memSampler{interval: interval}
+
+	return l.withProcessor(func(e entry) []any {
+		ms := s.get()
+
+		return append(e.kv, "heap_alloc_mb", ms.heapAllocMB, "gc_count", ms.gcCount, "gc_pause_ms", ms.gcPauseMS)
+	})
+}
@@ -0,0 +1,42 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithMemStats(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithMemStats(time.Hour)
+
+	c.Info(context.Background(), "first")
+	c.Info(context.Background(), "second")
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Greater(t, first["heap_alloc_mb"], 0.0)
+	assert.Contains(t, first, "gc_count")
+	assert.Contains(t, first, "gc_pause_ms")
+
+	// Second entry reuses snapshot within interval.
+	assert.Equal(t, first["heap_alloc_mb"], second["heap_alloc_mb"])
+}
@@ -0,0 +1,97 @@
+package zapctxd
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type metrics struct {
+	entries [zapcore.FatalLevel - zapcore.DebugLevel + 1]atomic.Int64
+	dropped atomic.Int64
+	errors  atomic.Int64
+}
+
+func (m *metrics) write(level zapcore.Level) {
+	if m == nil || level < zapcore.DebugLevel || level > zapcore.FatalLevel {
+		return
+	}
+
+	m.entries[level-zapcore.DebugLevel].Add(1)
+
+	if level >= zapcore.ErrorLevel {
+		m.errors.Add(1)
+	}
+}
+
+func (m *metrics) drop() {
+	if m == nil {
+		return
+	}
+
+	m.dropped.Add(1)
+}
+
+// LoggerMetrics contains counters of logger entries.
+type LoggerMetrics struct {
+	// Entries is a number of written entries by level name.
+	Entries map[string]int64 `json:"entries"`
+	// DroppedEntries is a number of entries dropped by filters or full buffers.
+	DroppedEntries int64 `json:"dropped_entries"`
+}
+
+// Metrics returns counters of entries written with contextualized methods.
+//
+// Counters are shared by derived loggers.
+func (l *Logger) Metrics() LoggerMetrics {
+	lm := LoggerMetrics{
+		Entries: make(map[string]int64),
+	}
+
+	if l.metrics == nil {
+		return lm
+	}
+
+	for i := range l.metrics.entries {
+		lm.Entries[(zapcore.DebugLevel + zapcore.Level(i)).String()] = l.metrics.entries[i].Load()
+	}
+
+	lm.DroppedEntries = l.metrics.dropped.Load()
+
+	return lm
+}
+
+// ErrorCount returns a number of entries of Error level and above written since construction
+// or Logger.ResetErrorCount, counter is shared by derived loggers.
+//
+// It can be used in liveness probes to detect persistent failures.
+func (l *Logger) ErrorCount() int64 {
+	if l.metrics == nil {
+		return 0
+	}
+
+	return l.metrics.errors.Load()
+}
+
+// ResetErrorCount resets counter of Logger.ErrorCount, Logger.Metrics are not affected.
+func (l *Logger) ResetErrorCount() {
+	if l.metrics != nil {
+		l.metrics.errors.Store(0)
+	}
+}
+
+// LoggerSnapshot describes current state of logger.
+type LoggerSnapshot struct {
+	Level   string        `json:"level"`
+	DevMode bool          `json:"dev_mode"`
+	Metrics LoggerMetrics `json:"metrics"`
+}
+
+// Snapshot returns current state of logger.
+func (l *Logger) Snapshot() LoggerSnapshot {
+	return LoggerSnapshot{
+		Level:   zapcore.LevelOf(l.levelEnabler.get()).String(),
+		DevMode: l.devMode,
+		Metrics: l.Metrics(),
+	}
+}
@@ -0,0 +1,37 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_ErrorCount(t *testing.T) {
+	c := zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	})
+
+	ctx := context.Background()
+
+	c.Info(ctx, "hello")
+	c.Warn(ctx, "warning")
+	c.Error(ctx, "failed")
+	c.ErrorZ(ctx, "failed")
+	c.With("foo", "bar").Errorf(ctx, "failed %d", 3)
+
+	assert.PanicsWithValue(t, "panic", func() {
+		c.Panic(ctx, "panic")
+	})
+
+	assert.Equal(t, int64(4), c.ErrorCount())
+
+	c.ResetErrorCount()
+	c.Error(ctx, "failed")
+
+	assert.Equal(t, int64(1), c.ErrorCount())
+	assert.Equal(t, int64(4), c.Metrics().Entries["error"])
+}
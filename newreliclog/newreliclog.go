@@ -0,0 +1,81 @@
+// Package newreliclog reports log entries of zapctxd.Logger to New Relic.
+package newreliclog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bool64/zapctxd"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// EventRecorder records custom events, it is implemented by *newrelic.Application.
+type EventRecorder interface {
+	RecordCustomEvent(eventType string, params map[string]any)
+}
+
+// LinkingFields returns "trace.id" and "span.id" context fields of New Relic transaction in context.
+//
+// Fields can be added with zapctxd.Logger.WithContextFields to correlate entries and events of ErrorEvents
+// with transaction.
+func LinkingFields() func(ctx context.Context) []any {
+	return func(ctx context.Context) []any {
+		md := newrelic.FromContext(ctx).GetLinkingMetadata()
+		if md.TraceID == "" {
+			return nil
+		}
+
+		kv := []any{"trace.id", md.TraceID}
+
+		if md.SpanID != "" {
+			kv = append(kv, "span.id", md.SpanID)
+		}
+
+		return kv
+	}
+}
+
+// ErrorEvents returns a hook that records written error entries as "LogError" custom events of app.
+//
+// Event has "level", "message" and fields of entry as attributes, values of unsupported types are
+// formatted as strings. Hook can be added with zapctxd.Logger.WithHook.
+func ErrorEvents(app EventRecorder) zapctxd.Hook {
+	return hook{app: app}
+}
+
+type hook struct {
+	app EventRecorder
+}
+
+func (h hook) Fire(level zapcore.Level, msg string, fields []zapcore.Field) error {
+	if level < zap.ErrorLevel {
+		return nil
+	}
+
+	attrs := make(map[string]any, 2+len(fields))
+
+	for _, f := range fields {
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+
+		attrs[f.Key] = attribute(enc.Fields[f.Key])
+	}
+
+	attrs["level"] = level.String()
+	attrs["message"] = msg
+
+	h.app.RecordCustomEvent("LogError", attrs)
+
+	return nil
+}
+
+func attribute(v any) any {
+	switch v.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
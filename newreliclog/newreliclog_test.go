@@ -0,0 +1,58 @@
+package newreliclog_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/newreliclog"
+)
+
+type eventRecorder struct {
+	events []map[string]any
+}
+
+func (r *eventRecorder) RecordCustomEvent(eventType string, params map[string]any) {
+	params["eventType"] = eventType
+	r.events = append(r.events, params)
+}
+
+func TestErrorEvents(t *testing.T) {
+	app, err := newrelic.NewApplication(
+		newrelic.ConfigAppName("test"),
+		newrelic.ConfigLicense("0123456789012345678901234567890123456789"),
+		newrelic.ConfigEnabled(false),
+		newrelic.ConfigDistributedTracerEnabled(true),
+	)
+	require.NoError(t, err)
+
+	txn := app.StartTransaction("test")
+	defer txn.End()
+
+	traceID := txn.GetLinkingMetadata().TraceID
+	require.NotEmpty(t, traceID)
+
+	r := &eventRecorder{}
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: io.Discard,
+	}).WithContextFields(newreliclog.LinkingFields()).WithHook(newreliclog.ErrorEvents(r))
+
+	ctx := ctxd.AddFields(context.Background(), "foo", 1)
+
+	c.Warn(ctx, "skipped")
+	c.Error(ctx, "failed", "elapsed", time.Second)
+	c.Error(newrelic.NewContext(ctx, txn), "traced")
+
+	assert.Equal(t, []map[string]any{
+		{"eventType": "LogError", "level": "error", "message": "failed", "foo": int64(1), "elapsed": "1s"},
+		{"eventType": "LogError", "level": "error", "message": "traced", "foo": int64(1), "trace.id": traceID},
+	}, r.events)
+}
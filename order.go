@@ -0,0 +1,29 @@
+package zapctxd
+
+// WithOrderedFields returns a logger that puts fields with provided keys first in the given order.
+//
+// Other fields follow in their natural order.
+func (l *Logger) WithOrderedFields(keys ...string) *Logger {
+	return l.withProcessor(func(e entry) []any {
+		kv := e.kv
+		pos := 0
+
+		for _, key := range keys {
+			for i := pos; i < len(kv)-1; i += 2 {
+				if k, ok := kv[i].(string); !ok || k != key {
+					continue
+				}
+
+				k, v := kv[i], kv[i+1]
+
+				copy(kv[pos+2:i+2], kv[pos:i])
+				kv[pos], kv[pos+1] = k, v
+				pos += 2
+
+				break
+			}
+		}
+
+		return kv
+	})
+}
@@ -0,0 +1,28 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithOrderedFields(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithOrderedFields("service", "missing", "request_id")
+
+	ctx := ctxd.AddFields(context.Background(), "request_id", "abc", "service", "api")
+
+	c.Info(ctx, "hello", "foo", 1, "bar", 2)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","service":"api","request_id":"abc","foo":1,"bar":2}
+`, w.String())
+}
@@ -0,0 +1,112 @@
+// Package otellog connects log entries of zapctxd.Logger with OpenTelemetry traces.
+package otellog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bool64/zapctxd"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// TraceFields returns context fields of trace and span IDs of valid span in context.
+//
+// Fields can be added with zapctxd.Logger.WithContextFields, empty keys default to "trace_id" and "span_id".
+func TraceFields(traceKey, spanKey string) func(ctx context.Context) []any {
+	if traceKey == "" {
+		traceKey = "trace_id"
+	}
+
+	if spanKey == "" {
+		spanKey = "span_id"
+	}
+
+	return func(ctx context.Context) []any {
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return nil
+		}
+
+		return []any{traceKey, sc.TraceID().String(), spanKey, sc.SpanID().String()}
+	}
+}
+
+// CloudTraceFields returns "logging.googleapis.com/trace" and "logging.googleapis.com/spanId" context fields
+// of valid span in context, so that Google Cloud Logging correlates entries with traces of Cloud Trace.
+//
+// Trace is formatted as "projects/{projectID}/traces/{traceID}".
+// Fields can be added with zapctxd.Logger.WithContextFields.
+func CloudTraceFields(projectID string) func(ctx context.Context) []any {
+	prefix := "projects/" + projectID + "/traces/"
+
+	return func(ctx context.Context) []any {
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return nil
+		}
+
+		return []any{
+			"logging.googleapis.com/trace", prefix + sc.TraceID().String(),
+			"logging.googleapis.com/spanId", sc.SpanID().String(),
+		}
+	}
+}
+
+// SpanEvents returns a hook that adds entries of minLevel and above as events of span.
+//
+// Event is named after message, it has "level", "message" and fields of entry as attributes.
+// Hook can be added with zapctxd.Logger.WithHook.
+func SpanEvents(span trace.Span, minLevel zapcore.Level) zapctxd.Hook {
+	return spanHook{span: span, minLevel: minLevel}
+}
+
+type spanHook struct {
+	span     trace.Span
+	minLevel zapcore.Level
+}
+
+func (h spanHook) Fire(level zapcore.Level, msg string, fields []zapcore.Field) error {
+	if level < h.minLevel {
+		return nil
+	}
+
+	attrs := make([]attribute.KeyValue, 0, 2+len(fields))
+	attrs = append(attrs, attribute.String("level", level.String()), attribute.String("message", msg))
+
+	for _, f := range fields {
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+
+		attrs = append(attrs, attributeOf(f.Key, enc.Fields[f.Key]))
+	}
+
+	h.span.AddEvent(msg, trace.WithAttributes(attrs...))
+
+	return nil
+}
+
+func attributeOf(k string, v any) attribute.KeyValue {
+	switch v := v.(type) {
+	case string:
+		return attribute.String(k, v)
+	case bool:
+		return attribute.Bool(k, v)
+	case int:
+		return attribute.Int(k, v)
+	case int64:
+		return attribute.Int64(k, v)
+	case float64:
+		return attribute.Float64(k, v)
+	case []string:
+		return attribute.StringSlice(k, v)
+	case time.Duration:
+		return attribute.String(k, v.String())
+	case fmt.Stringer:
+		return attribute.String(k, v.String())
+	default:
+		return attribute.String(k, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,92 @@
+package otellog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/otellog"
+)
+
+type recordingSpan struct {
+	trace.Span
+	names []string
+	attrs [][]attribute.KeyValue
+}
+
+func (s *recordingSpan) AddEvent(name string, options ...trace.EventOption) {
+	s.names = append(s.names, name)
+	cfg := trace.NewEventConfig(options...)
+	s.attrs = append(s.attrs, cfg.Attributes())
+}
+
+func TestSpanEvents(t *testing.T) {
+	span := &recordingSpan{Span: trace.SpanFromContext(context.Background())}
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	}).WithHook(otellog.SpanEvents(span, zapcore.WarnLevel))
+
+	c.Info(context.Background(), "skipped")
+	c.Warn(context.Background(), "slow query", "rows", 10, "table", "users")
+
+	assert.Equal(t, []string{"slow query"}, span.names)
+	assert.Equal(t, [][]attribute.KeyValue{{
+		attribute.String("level", "warn"),
+		attribute.String("message", "slow query"),
+		attribute.Int("rows", 10),
+		attribute.String("table", "users"),
+	}}, span.attrs)
+}
+
+func TestTraceFields(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithContextFields(otellog.TraceFields("", "span.id"))
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+
+	c.Info(trace.ContextWithSpanContext(context.Background(), sc), "traced", "foo", 1)
+	c.Info(context.Background(), "not traced")
+	c.Info(trace.ContextWithSpanContext(context.Background(), trace.SpanContext{}), "invalid span")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"traced","foo":1,"trace_id":"0102030405060708090a0b0c0d0e0f10","span.id":"0102030405060708"}
+{"level":"info","time":"<stripped>","msg":"not traced"}
+{"level":"info","time":"<stripped>","msg":"invalid span"}
+`, w.String())
+}
+
+func TestCloudTraceFields(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithContextFields(otellog.CloudTraceFields("my-project"))
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+
+	c.Info(trace.ContextWithSpanContext(context.Background(), sc), "traced")
+	c.Info(context.Background(), "not traced")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"traced",`+
+		`"logging.googleapis.com/trace":"projects/my-project/traces/0102030405060708090a0b0c0d0e0f10",`+
+		`"logging.googleapis.com/spanId":"0102030405060708"}
+{"level":"info","time":"<stripped>","msg":"not traced"}
+`, w.String())
+}
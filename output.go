@@ -0,0 +1,119 @@
+package zapctxd
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// withOutput returns a clone of logger that writes to ws, Close of the clone calls closer.
+func (l *Logger) withOutput(ws zapcore.WriteSyncer, closer func() error) (*Logger, error) {
+	if l.mu == nil {
+		return nil, errors.New("cannot change output of logger created with zap loggers")
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	nl := l.Clone()
+
+	nl.mu = &sync.RWMutex{}
+	nl.out = ws
+	nl.shards = nil
+	nl.closers = nil
+
+	if closer != nil {
+		nl.closers = []func() error{closer}
+	}
+
+	nl.make()
+
+	return nl, nil
+}
+
+// WithOutput returns a clone of logger that writes to w, e.g. a file of filelog.Rotating.
+//
+// Original logger is not affected, Close of the clone closes w if it implements io.Closer.
+func (l *Logger) WithOutput(w io.Writer) (*Logger, error) {
+	var closer func() error
+
+	if c, ok := w.(io.Closer); ok {
+		closer = c.Close
+	}
+
+	return l.withOutput(zapcore.AddSync(w), closer)
+}
+
+// OutputSpec defines an output with minimal level of entries.
+type OutputSpec struct {
+	Writer io.Writer
+	// MinLevel is a minimal level of entries written to Writer, default info.
+	MinLevel zapcore.Level
+}
+
+// newMultiOutput creates an output that writes entries to writers of specs by level.
+//
+// Single output is written with all entries, like Config.Output.
+func newMultiOutput(single io.Writer, specs []OutputSpec) zapcore.WriteSyncer {
+	m := &multiOutput{}
+
+	if single != nil {
+		m.outputs = append(m.outputs, levelOutput{ws: zapcore.AddSync(single), minLevel: zapcore.DebugLevel})
+	}
+
+	for _, s := range specs {
+		m.outputs = append(m.outputs, levelOutput{ws: zapcore.AddSync(s.Writer), minLevel: s.MinLevel})
+	}
+
+	return m
+}
+
+type levelOutput struct {
+	ws       zapcore.WriteSyncer
+	minLevel zapcore.Level
+}
+
+// multiOutput is similar to zapcore.NewMultiWriteSyncer, but filters entries by level.
+type multiOutput struct {
+	outputs []levelOutput
+}
+
+// Write writes entry of unknown level to all outputs.
+func (m *multiOutput) Write(p []byte) (int, error) {
+	var err error
+
+	for _, o := range m.outputs {
+		_, werr := o.ws.Write(p)
+		err = multierr.Append(err, werr)
+	}
+
+	return len(p), err
+}
+
+func (m *multiOutput) WriteLevel(level zapcore.Level, p []byte) error {
+	var err error
+
+	for _, o := range m.outputs {
+		if level < o.minLevel {
+			continue
+		}
+
+		_, werr := o.ws.Write(p)
+		err = multierr.Append(err, werr)
+	}
+
+	return err
+}
+
+func (m *multiOutput) Sync() error {
+	var err error
+
+	for _, o := range m.outputs {
+		err = multierr.Append(err, o.ws.Sync())
+	}
+
+	return err
+}
@@ -0,0 +1,74 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithOutput(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	fc, err := c.WithOutput(f)
+	require.NoError(t, err)
+
+	fc.Info(context.Background(), "to file")
+	c.Info(context.Background(), "to original")
+	require.NoError(t, fc.Close())
+
+	// File is closed with the clone.
+	assert.Error(t, f.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"to file"}
+`, string(b))
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"to original"}
+`, w.String())
+}
+
+func TestNew_outputs(t *testing.T) {
+	all := bytes.NewBuffer(nil)
+	file := bytes.NewBuffer(nil)
+	debug := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.DebugLevel,
+		StripTime: true,
+		Output:    all,
+		Outputs: []zapctxd.OutputSpec{
+			{Writer: file, MinLevel: zap.WarnLevel},
+			{Writer: debug, MinLevel: zap.DebugLevel},
+		},
+	})
+
+	ctx := context.Background()
+
+	c.Debug(ctx, "debug")
+	c.Warn(ctx, "warning")
+
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"warning"}
+`, file.String())
+	assert.Equal(t, `{"level":"debug","time":"<stripped>","msg":"debug"}
+{"level":"warn","time":"<stripped>","msg":"warning"}
+`, debug.String())
+	assert.Equal(t, debug.String(), all.String())
+}
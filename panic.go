@@ -0,0 +1,74 @@
+package zapctxd
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithStructuredPanic returns a logger that reports panics of encoding or writing entries.
+//
+// Panic is logged by original logger as error entry with context fields of ctx, "entry_msg",
+// "panic_value" and "stack", and then panic is resumed.
+func (l *Logger) WithStructuredPanic(ctx context.Context) *Logger {
+	nl := *l
+
+	report := func(ent zapcore.Entry, r any) {
+		l.Error(ctx, "panic while writing log entry",
+			"entry_msg", ent.Message, "panic_value", fmt.Sprintf("%v", r), "stack", string(debug.Stack()))
+	}
+
+	opt := zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return panicCore{Core: c, report: report}
+	})
+
+	nl.options = append(l.options[:len(l.options):len(l.options)], opt)
+	nl.zl = l.zl.WithOptions(opt)
+	nl.zdebug = l.zdebug.WithOptions(opt)
+	nl.sugared = nl.zl.Sugar()
+	nl.debug = nl.zdebug.Sugar()
+
+	return &nl
+}
+
+// WithPanicStack returns a logger that adds "panic_stack" field with stack trace of Logger.Panic call site,
+// so that origin of panic is logged even if panic is recovered upstream.
+func (l *Logger) WithPanicStack() *Logger {
+	return l.withProcessor(func(e entry) []any {
+		if e.level != zap.PanicLevel {
+			return e.kv
+		}
+
+		return append(e.kv, "panic_stack", string(debug.Stack()))
+	})
+}
+
+// panicCore reports panics of underlying core.
+type panicCore struct {
+	zapcore.Core
+
+	report func(ent zapcore.Entry, r any)
+}
+
+func (c panicCore) With(fields []zapcore.Field) zapcore.Core {
+	return panicCore{Core: c.Core.With(fields), report: c.report}
+}
+
+func (c panicCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapper(c.Core, c, ent, ce)
+}
+
+func (c panicCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	defer func() {
+		if r := recover(); r != nil {
+			c.report(ent, r)
+
+			panic(r)
+		}
+	}()
+
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,136 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+type panickingValue struct{}
+
+func (panickingValue) MarshalJSON() ([]byte, error) {
+	panic("marshal failed")
+}
+
+func TestLogger_WithStructuredPanic(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	p := c.WithStructuredPanic(ctxd.AddFields(context.Background(), "service", "api"))
+
+	p.Info(context.Background(), "hello")
+
+	assert.PanicsWithValue(t, "marshal failed", func() {
+		p.Info(context.Background(), "broken", "value", panickingValue{})
+	})
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello"}`, lines[0])
+
+	var e map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	assert.Equal(t, "error", e["level"])
+	assert.Equal(t, "panic while writing log entry", e["msg"])
+	assert.Equal(t, "broken", e["entry_msg"])
+	assert.Equal(t, "marshal failed", e["panic_value"])
+	assert.Equal(t, "api", e["service"])
+	assert.Contains(t, e["stack"], "panicCore")
+}
+
+func TestLogger_WithPanicStack(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: w,
+	}).WithPanicStack()
+
+	c.Error(context.Background(), "failed")
+
+	func() {
+		defer func() {
+			assert.Equal(t, "boom", recover())
+		}()
+
+		c.Panic(context.Background(), "boom", "foo", "bar")
+	}()
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.NotContains(t, lines[0], "panic_stack")
+
+	var e map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	assert.Equal(t, "bar", e["foo"])
+	assert.Contains(t, e["panic_stack"], "zapctxd_test.TestLogger_WithPanicStack")
+}
+
+func TestConfig_ErrorStackTrace(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output:          w,
+		ErrorStackTrace: true,
+	})
+
+	_, file, line, _ := runtime.Caller(0)
+
+	c.Warn(context.Background(), "warning")
+	c.Error(context.Background(), "failed", "error", errors.New("failed"))
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.NotContains(t, lines[0], "stacktrace")
+
+	var e struct {
+		Stacktrace string `json:"stacktrace"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	assert.True(t, strings.HasPrefix(e.Stacktrace, "github.com/bool64/zapctxd_test.TestConfig_ErrorStackTrace\n\t"+
+		fmt.Sprintf("%s:%d\n", file, line+3)), e.Stacktrace)
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		Output:             w,
+		ErrorStackTrace:    true,
+		StackTraceMinLevel: zap.WarnLevel,
+	})
+
+	c.Warn(context.Background(), "warning")
+	assert.Contains(t, w.String(), `"stacktrace":"github.com/bool64/zapctxd_test.TestConfig_ErrorStackTrace\n`)
+}
+
+func TestLogger_WithStructuredPanic_sampling(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output:   w,
+		Sampling: &zapctxd.SamplingConfig{Initial: 1, Thereafter: 100},
+	}).WithStructuredPanic(context.Background())
+
+	for i := 0; i < 100; i++ {
+		c.Info(context.Background(), "hello")
+	}
+
+	assert.Equal(t, 1, strings.Count(w.String(), "\n"))
+}
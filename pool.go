@@ -0,0 +1,59 @@
+package zapctxd
+
+import (
+	"context"
+
+	"github.com/bool64/ctxd"
+)
+
+// ContextLoggerPool reuses loggers scoped to request contexts.
+//
+// Pooled loggers share cores of the parent logger, fields of context are added to each entry
+// instead of being encoded into new cores.
+type ContextLoggerPool struct {
+	logger *Logger
+	free   chan *Logger
+}
+
+// NewContextLoggerPool creates a pool with capacity of pre-allocated loggers derived from logger.
+func NewContextLoggerPool(logger *Logger, capacity int) *ContextLoggerPool {
+	p := &ContextLoggerPool{
+		logger: logger,
+		free:   make(chan *Logger, capacity),
+	}
+
+	for i := 0; i < capacity; i++ {
+		p.free <- &Logger{}
+	}
+
+	return p
+}
+
+// Get returns a logger with fields of ctx attached and a function to return logger to the pool.
+//
+// Logger and loggers derived from it must not be used after release.
+// Fields of ctx are already applied, so entries should be logged with a context that does not carry them again.
+func (p *ContextLoggerPool) Get(ctx context.Context) (*Logger, func()) {
+	var l *Logger
+
+	select {
+	case l = <-p.free:
+	default:
+		l = &Logger{}
+	}
+
+	// Backing array of fields is kept between uses of pooled logger.
+	scoped := append(l.scoped[:0], p.logger.scoped...)
+
+	*l = *p.logger
+	l.scoped = append(scoped, ctxd.Fields(ctx)...)
+
+	return l, func() {
+		*l = Logger{scoped: l.scoped[:0]}
+
+		select {
+		case p.free <- l:
+		default:
+		}
+	}
+}
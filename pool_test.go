@@ -0,0 +1,54 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestContextLoggerPool_Get(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	p := zapctxd.NewContextLoggerPool(zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}), 1)
+
+	ctx := ctxd.AddFields(context.Background(), "request_id", "r1")
+
+	l, release := p.Get(ctx)
+	l.Info(context.Background(), "hello", "foo", "bar")
+	release()
+
+	l, release = p.Get(ctxd.AddFields(context.Background(), "request_id", "r2"))
+	l.Info(context.Background(), "hello again")
+	release()
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","request_id":"r1","foo":"bar"}
+{"level":"info","time":"<stripped>","msg":"hello again","request_id":"r2"}
+`, w.String())
+}
+
+func TestContextLoggerPool_Get_typed(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	p := zapctxd.NewContextLoggerPool(zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).With("app", "test"), 1)
+
+	l, release := p.Get(ctxd.AddFields(context.Background(), "request_id", "r1"))
+	l.InfoZ(context.Background(), "typed", zap.Int("foo", 1))
+	l.With("bar", 2).Info(context.Background(), "derived")
+	release()
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"typed","app":"test","request_id":"r1","foo":1}
+{"level":"info","time":"<stripped>","msg":"derived","app":"test","bar":2,"request_id":"r1"}
+`, w.String())
+}
@@ -0,0 +1,129 @@
+package zapctxd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bool64/ctxd"
+	"go.uber.org/zap"
+)
+
+// Debugf logs debug message with message formatted according to format specifier.
+func (l *Logger) Debugf(ctx context.Context, format string, args ...any) {
+	z := l.get(ctx, zap.DebugLevel)
+	if z == nil {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	e := entry{ctx: ctx, level: zap.DebugLevel, msg: msg}
+	kv := l.prepareKV(e, nil)
+
+	if l.skip(e, kv) {
+		return
+	}
+
+	z.Debugw(msg, kv...)
+	l.written(e, kv)
+}
+
+// Infof logs informational message with message formatted according to format specifier.
+func (l *Logger) Infof(ctx context.Context, format string, args ...any) {
+	z := l.get(ctx, zap.InfoLevel)
+	if z == nil {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	e := entry{ctx: ctx, level: zap.InfoLevel, msg: msg}
+	kv := l.prepareKV(e, nil)
+
+	if l.skip(e, kv) {
+		return
+	}
+
+	z.Infow(msg, kv...)
+	l.written(e, kv)
+}
+
+// Importantf logs important message that is not filtered by level with message formatted according to format specifier.
+func (l *Logger) Importantf(ctx context.Context, format string, args ...any) {
+	z := l.get(ctxd.WithDebug(ctx), zap.InfoLevel)
+	if z == nil {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	e := entry{ctx: ctx, level: zap.InfoLevel, important: true, msg: msg}
+	kv := l.prepareKV(e, nil)
+
+	if l.skip(e, kv) {
+		return
+	}
+
+	z.Infow(msg, kv...)
+	l.written(e, kv)
+}
+
+// Warnf logs warning message with message formatted according to format specifier.
+func (l *Logger) Warnf(ctx context.Context, format string, args ...any) {
+	z := l.get(ctx, zap.WarnLevel)
+	if z == nil {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	e := entry{ctx: ctx, level: zap.WarnLevel, msg: msg}
+	kv := l.prepareKV(e, nil)
+
+	if l.skip(e, kv) {
+		return
+	}
+
+	z.Warnw(msg, kv...)
+	l.written(e, kv)
+}
+
+// Errorf logs error message with message formatted according to format specifier.
+func (l *Logger) Errorf(ctx context.Context, format string, args ...any) {
+	z := l.get(ctx, zap.ErrorLevel)
+	if z == nil {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+
+	if l.strict {
+		l.checkStrict(ctx, msg, nil)
+	}
+
+	e := entry{ctx: ctx, level: zap.ErrorLevel, msg: msg}
+	kv := l.prepareKV(e, nil)
+
+	if l.skip(e, kv) {
+		return
+	}
+
+	z.Errorw(msg, kv...)
+	l.written(e, kv)
+}
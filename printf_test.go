@@ -0,0 +1,41 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_Infof(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.WarnLevel,
+		StripTime: true,
+		Output:    w,
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "foo", 1)
+
+	c.Debugf(ctx, "skipped %d", 1)
+	c.Infof(ctx, "skipped %d", 2)
+	c.Importantf(ctx, "important %d", 3)
+	c.Warnf(ctx, "warning %q", "quoted")
+	c.Errorf(ctx, "error %v", assert.AnError)
+
+	buf := bytes.NewBuffer(nil)
+	c.Infof(ctxd.WithDebug(ctxd.WithLogWriter(ctx, buf)), "redirected %d", 4)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"important 3","foo":1}
+{"level":"warn","time":"<stripped>","msg":"warning \"quoted\"","foo":1}
+{"level":"error","time":"<stripped>","msg":"error assert.AnError general error for testing","foo":1}
+`, w.String())
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"redirected 4","foo":1}
+`, buf.String())
+}
@@ -0,0 +1,122 @@
+package zapctxd
+
+import "go.uber.org/zap"
+
+const (
+	callSitePriority       = 100
+	defaultContextPriority = 50
+)
+
+// PriorityField is a key-value pair with priority, it is created with Priority.
+type PriorityField struct {
+	key      string
+	value    any
+	priority int
+}
+
+// Priority returns a key-value pair that replaces other values of the same key with lower priority.
+//
+// It can be used in place of key and value in call-site arguments or in context fields of ctxd.AddFields.
+// Call-site fields have priority 100, context fields have priority 50 or Config.DefaultContextPriority.
+// Keys without prioritized values are not deduplicated, of values with equal priority the first is kept.
+func Priority(key string, value any, priority int) PriorityField {
+	return PriorityField{key: key, value: value, priority: priority}
+}
+
+// hasPriority checks if key-value pairs contain PriorityField.
+func hasPriority(kv []any) bool {
+	for _, v := range kv {
+		if _, ok := v.(PriorityField); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolvePriority expands PriorityField items and removes values of prioritized keys that have lower priority,
+// first n items of kv are call-site arguments.
+func (l *Logger) resolvePriority(kv []any, n int) []any {
+	ctxPriority := defaultContextPriority
+	if l.cfg.DefaultContextPriority != 0 {
+		ctxPriority = l.cfg.DefaultContextPriority
+	}
+
+	type pair struct {
+		key, value any
+		priority   int
+		single     bool
+	}
+
+	var (
+		pairs    = make([]pair, 0, len(kv)/2+1)
+		best     = map[string]int{}
+		kept     = map[string]bool{}
+		trailing []any
+	)
+
+	for i := 0; i < len(kv); i++ {
+		if p, ok := kv[i].(PriorityField); ok {
+			pairs = append(pairs, pair{key: p.key, value: p.value, priority: p.priority})
+
+			if pr, ok := best[p.key]; !ok || p.priority > pr {
+				best[p.key] = p.priority
+			}
+
+			continue
+		}
+
+		if _, ok := kv[i].(zap.Field); ok {
+			pairs = append(pairs, pair{key: kv[i], single: true})
+
+			continue
+		}
+
+		if i == len(kv)-1 {
+			trailing = kv[i:]
+
+			break
+		}
+
+		p := pair{key: kv[i], value: kv[i+1], priority: callSitePriority}
+		if i >= n {
+			p.priority = ctxPriority
+		}
+
+		pairs = append(pairs, p)
+		i++
+	}
+
+	for _, p := range pairs {
+		if k, ok := p.key.(string); ok {
+			if pr, ok := best[k]; ok && p.priority > pr {
+				best[k] = p.priority
+			}
+		}
+	}
+
+	res := make([]any, 0, 2*len(pairs)+len(trailing))
+
+	for _, p := range pairs {
+		if k, ok := p.key.(string); ok {
+			if pr, ok := best[k]; ok {
+				// Only the first of values with winning priority is kept.
+				if p.priority != pr || kept[k] {
+					continue
+				}
+
+				kept[k] = true
+			}
+		}
+
+		if p.single {
+			res = append(res, p.key)
+
+			continue
+		}
+
+		res = append(res, p.key, p.value)
+	}
+
+	return append(res, trailing...)
+}
@@ -0,0 +1,46 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestPriority(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "user", "ctx-user", "role", "ctx-role")
+	ctx = ctxd.AddFields(ctx, zapctxd.Priority("tenant", "ctx-tenant", 200))
+
+	c.Info(ctx, "context priority", "user", "call-user", zapctxd.Priority("role", "call-role", 10), "tenant", "call-tenant")
+	c.Info(ctx, "no priority", "user", "call-user")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"context priority","user":"call-user","user":"ctx-user","role":"ctx-role","tenant":"ctx-tenant"}
+{"level":"info","time":"<stripped>","msg":"no priority","user":"call-user","user":"ctx-user","role":"ctx-role","tenant":"ctx-tenant"}
+`, w.String())
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime:              true,
+		Output:                 w,
+		DefaultContextPriority: 150,
+	})
+
+	ctx = ctxd.AddFields(context.Background(), "user", "ctx-user")
+
+	c.Info(ctx, "context wins", zapctxd.Priority("user", "call-user", 100))
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"context wins","user":"ctx-user"}
+`, w.String())
+}
@@ -0,0 +1,84 @@
+package zapctxd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WithCPUProfile returns a logger that captures CPU profile of duration when an entry of Error level
+// or above is written.
+//
+// Profile is written to dir/<timestamp>.pprof in background, so that logging is not blocked.
+// Only one profile is captured at a time, errors during profiling are skipped.
+func (l *Logger) WithCPUProfile(dir string, duration time.Duration) *Logger {
+	var mu sync.Mutex
+
+	return l.withHook(func(e entry) {
+		if e.level < zap.ErrorLevel || !mu.TryLock() {
+			return
+		}
+
+		go func() {
+			defer mu.Unlock()
+
+			if err := cpuProfile(dir, duration); err != nil {
+				l.sugared.Warnw("failed to capture CPU profile", "error", err.Error(), "entry_msg", e.msg)
+			}
+		}()
+	})
+}
+
+func cpuProfile(dir string, duration time.Duration) error {
+	name := filepath.Join(dir, time.Now().UTC().Format("20060102T150405.000000000")+".pprof")
+
+	f, err := os.Create(name) //nolint:gosec // Path is configured by user.
+	if err != nil {
+		return err
+	}
+
+	if err := pprof.StartCPUProfile(f); err != nil {
+		_ = f.Close()       //nolint:errcheck // Error of start is reported instead.
+		_ = os.Remove(name) //nolint:errcheck // Error of start is reported instead.
+
+		return err
+	}
+
+	time.Sleep(duration)
+	pprof.StopCPUProfile()
+
+	return f.Close()
+}
+
+// WithProfiler returns a logger that adds "caller_stack" field with stack trace of current goroutine
+// to entries for which sampler returns true.
+//
+// It helps to find code paths that generate most of log traffic, for example with
+// sampler func() bool { return rand.Float32() < 0.001 } 0.1% of entries have stack traces.
+func (l *Logger) WithProfiler(sampler func() bool) *Logger {
+	return l.withProcessor(func(e entry) []any {
+		if !sampler() {
+			return e.kv
+		}
+
+		return append(e.kv, "caller_stack", callerStack())
+	})
+}
+
+func callerStack() string {
+	buf := make([]byte, 4096)
+
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,60 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithCPUProfile(t *testing.T) {
+	dir := t.TempDir()
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	}).WithCPUProfile(dir, 10*time.Millisecond)
+
+	c.Warn(context.Background(), "not profiled")
+	c.Error(context.Background(), "profiled")
+	c.Error(context.Background(), "skipped while profiling")
+
+	assert.Eventually(t, func() bool {
+		files, err := filepath.Glob(filepath.Join(dir, "*.pprof"))
+		if err != nil || len(files) != 1 {
+			return false
+		}
+
+		fi, err := os.Stat(files[0])
+
+		return err == nil && fi.Size() > 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLogger_WithProfiler(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	i := 0
+	c := zapctxd.New(zapctxd.Config{
+		Output: w,
+	}).WithProfiler(func() bool {
+		i++
+
+		return i%2 == 0
+	})
+
+	c.Info(context.Background(), "first")
+	c.Info(context.Background(), "second")
+
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.NotContains(t, lines[0], "caller_stack")
+	assert.Contains(t, lines[1], `"caller_stack":"goroutine `)
+	assert.Contains(t, lines[1], "TestLogger_WithProfiler")
+}
@@ -0,0 +1,52 @@
+// Package promlog provides Prometheus metrics of written log entries.
+package promlog
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLevelCounter creates a counter of written entries with level and logger name labels.
+//
+// Counter is not registered, it can be added to any prometheus.Registerer.
+func NewLevelCounter(namespace string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "log_entries_total",
+		Help:      "Number of written log entries.",
+	}, []string{"level", "logger_name"})
+}
+
+// CountEntries returns an option that increments counter on every write to logger core.
+//
+// Option can be used in zapctxd.Config.ZapOptions with counter of NewLevelCounter.
+func CountEntries(counter *prometheus.CounterVec) zap.Option {
+	return zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return counterCore{Core: c, counter: counter}
+	})
+}
+
+type counterCore struct {
+	zapcore.Core
+
+	counter *prometheus.CounterVec
+}
+
+func (c counterCore) With(fields []zapcore.Field) zapcore.Core {
+	return counterCore{Core: c.Core.With(fields), counter: c.counter}
+}
+
+func (c counterCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+func (c counterCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.counter.WithLabelValues(ent.Level.String(), ent.LoggerName).Inc()
+
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,63 @@
+package promlog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/promlog"
+)
+
+func TestCountEntries(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := promlog.NewLevelCounter("app")
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:      zap.DebugLevel,
+		Output:     bytes.NewBuffer(nil),
+		ZapOptions: []zap.Option{promlog.CountEntries(counter)},
+	})
+
+	require.NoError(t, registry.Register(counter))
+
+	ctx := context.Background()
+
+	c.Debug(ctx, "debug")
+	c.Info(ctx, "info")
+	c.Important(ctx, "important")
+	c.Warn(ctx, "warn")
+	c.Error(ctx, "error")
+	c.Error(ctx, "error")
+	c.Named("worker").Error(ctx, "error")
+
+	mfs, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, mfs, 1)
+	assert.Equal(t, "app_log_entries_total", mfs[0].GetName())
+
+	counts := map[string]float64{}
+
+	for _, m := range mfs[0].GetMetric() {
+		labels := map[string]string{}
+
+		for _, lp := range m.GetLabel() {
+			labels[lp.GetName()] = lp.GetValue()
+		}
+
+		counts[labels["level"]+"/"+labels["logger_name"]] = m.GetCounter().GetValue()
+	}
+
+	assert.Equal(t, map[string]float64{
+		"debug/":       1,
+		"info/":        2,
+		"warn/":        1,
+		"error/":       2,
+		"error/worker": 1,
+	}, counts)
+}
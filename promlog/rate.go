@@ -0,0 +1,102 @@
+package promlog
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/bool64/zapctxd"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap/zapcore"
+)
+
+// rateBuckets is a number of buckets in sliding window.
+const rateBuckets = 10
+
+type rateBucket struct {
+	slot  atomic.Int64
+	count atomic.Int64
+}
+
+// rateWindow counts events in a sliding window with a ring of buckets.
+type rateWindow struct {
+	bucket  time.Duration
+	buckets [rateBuckets]rateBucket
+}
+
+func newRateWindow(size time.Duration) *rateWindow {
+	b := size / rateBuckets
+	if b <= 0 {
+		b = 1
+	}
+
+	return &rateWindow{bucket: b}
+}
+
+func (w *rateWindow) add(now time.Time) {
+	slot := now.UnixNano() / int64(w.bucket)
+	b := &w.buckets[slot%rateBuckets]
+
+	if s := b.slot.Load(); s != slot && b.slot.CompareAndSwap(s, slot) {
+		b.count.Store(0)
+	}
+
+	b.count.Add(1)
+}
+
+// rate returns number of events per second in window.
+func (w *rateWindow) rate(now time.Time) float64 {
+	slot := now.UnixNano() / int64(w.bucket)
+
+	var total int64
+
+	for i := range w.buckets {
+		b := &w.buckets[i]
+
+		if s := b.slot.Load(); s > slot-rateBuckets && s <= slot {
+			total += b.count.Load()
+		}
+	}
+
+	return float64(total) / (time.Duration(rateBuckets) * w.bucket).Seconds()
+}
+
+// WithRateMetrics returns a logger that exposes per-level rates of written entries in registry.
+//
+// Rates are measured in a sliding window of windowSize and exposed as
+// log_rate_per_second{level="error"} gauges.
+func WithRateMetrics(l *zapctxd.Logger, registry prometheus.Registerer, windowSize time.Duration) *zapctxd.Logger {
+	var h rateHook
+
+	for i := range h.windows {
+		w := newRateWindow(windowSize)
+		h.windows[i] = w
+
+		g := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name:        "log_rate_per_second",
+			Help:        "Rate of written log entries per second.",
+			ConstLabels: prometheus.Labels{"level": (zapcore.DebugLevel + zapcore.Level(i)).String()},
+		}, func() float64 {
+			return w.rate(time.Now())
+		})
+
+		if err := registry.Register(g); err != nil {
+			l.Warn(context.Background(), "failed to register log rate metric", "error", err)
+		}
+	}
+
+	return l.WithHook(&h)
+}
+
+// rateHook counts written entries in windows of levels.
+type rateHook struct {
+	windows [zapcore.FatalLevel - zapcore.DebugLevel + 1]*rateWindow
+}
+
+func (h *rateHook) Fire(level zapcore.Level, _ string, _ []zapcore.Field) error {
+	if level >= zapcore.DebugLevel && level <= zapcore.FatalLevel {
+		h.windows[level-zapcore.DebugLevel].add(time.Now())
+	}
+
+	return nil
+}
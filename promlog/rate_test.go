@@ -0,0 +1,44 @@
+package promlog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/promlog"
+)
+
+func TestWithRateMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	c := promlog.WithRateMetrics(zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	}), registry, 10*time.Second)
+
+	for i := 0; i < 20; i++ {
+		c.Error(context.Background(), "failed")
+	}
+
+	c.Info(context.Background(), "hello")
+
+	mfs, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, mfs, 1)
+	assert.Equal(t, "log_rate_per_second", mfs[0].GetName())
+
+	rates := map[string]float64{}
+
+	for _, m := range mfs[0].GetMetric() {
+		rates[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+	}
+
+	assert.Equal(t, 2.0, rates["error"])
+	assert.Equal(t, 0.1, rates["info"])
+	assert.Equal(t, 0.0, rates["warn"])
+}
@@ -0,0 +1,134 @@
+package zapctxd
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultRateLimitMaxKeys = 1024
+	rateLimitWindow         = time.Second
+)
+
+// RateLimitConfig configures rate limiting of entries with the same level and message.
+//
+// Important entries and entries of panic and fatal levels are not limited.
+type RateLimitConfig struct {
+	// MessagesPerSecond is a rate of allowed entries with the same level and message.
+	MessagesPerSecond float64 `split_words:"true"`
+	// BurstSize is a number of entries allowed in a burst, default is MessagesPerSecond, but at least 1.
+	BurstSize int `split_words:"true"`
+	// MaxKeys is a maximum number of tracked messages, tracking is reset when it is exceeded, default 1024.
+	MaxKeys int `split_words:"true"`
+}
+
+// rateLimiter drops entries beyond the rate, number of dropped entries is reported with "log rate limit hit"
+// entry at the same level a second after the first dropped entry, before the next allowed entry,
+// on Sync or on Close, whichever comes first.
+type rateLimiter struct {
+	logger  *Logger
+	limit   rate.Limit
+	burst   int
+	maxKeys int64
+
+	keys     atomic.Int64
+	limiters sync.Map // map[string]*rateLimited
+}
+
+type rateLimited struct {
+	level   zapcore.Level
+	msg     string
+	lim     *rate.Limiter
+	dropped atomic.Int64
+}
+
+func (l *Logger) rateLimiter(cfg RateLimitConfig) *rateLimiter {
+	rl := &rateLimiter{
+		logger:  l,
+		limit:   rate.Limit(cfg.MessagesPerSecond),
+		burst:   cfg.BurstSize,
+		maxKeys: int64(cfg.MaxKeys),
+	}
+
+	if rl.burst <= 0 {
+		rl.burst = int(cfg.MessagesPerSecond)
+	}
+
+	if rl.burst < 1 {
+		rl.burst = 1
+	}
+
+	if rl.maxKeys <= 0 {
+		rl.maxKeys = defaultRateLimitMaxKeys
+	}
+
+	return rl
+}
+
+// filter returns true for entries beyond the rate.
+func (rl *rateLimiter) filter(e entry) bool {
+	if e.important || e.level > zap.ErrorLevel {
+		return false
+	}
+
+	k := e.level.String() + "\x00" + e.msg
+
+	v, ok := rl.limiters.Load(k)
+	if !ok {
+		if rl.keys.Load() >= rl.maxKeys {
+			rl.reset()
+		}
+
+		v, ok = rl.limiters.LoadOrStore(k, &rateLimited{level: e.level, msg: e.msg, lim: rate.NewLimiter(rl.limit, rl.burst)})
+		if !ok {
+			rl.keys.Add(1)
+		}
+	}
+
+	r := v.(*rateLimited) //nolint:errcheck // Type is controlled.
+
+	if !r.lim.Allow() {
+		if r.dropped.Add(1) == 1 {
+			time.AfterFunc(rateLimitWindow, func() { rl.report(r) })
+		}
+
+		return true
+	}
+
+	rl.report(r)
+
+	return false
+}
+
+func (rl *rateLimiter) report(r *rateLimited) {
+	if dropped := r.dropped.Swap(0); dropped > 0 {
+		rl.logger.sugared.Logw(r.level, "log rate limit hit", "entry_msg", r.msg, "dropped", dropped)
+	}
+}
+
+// flush reports dropped entries.
+func (rl *rateLimiter) flush() error {
+	rl.limiters.Range(func(_, v any) bool {
+		rl.report(v.(*rateLimited)) //nolint:errcheck // Type is controlled.
+
+		return true
+	})
+
+	return nil
+}
+
+// reset reports dropped entries and stops tracking messages.
+func (rl *rateLimiter) reset() {
+	rl.limiters.Range(func(k, v any) bool {
+		rl.limiters.Delete(k)
+		rl.keys.Add(-1)
+		rl.report(v.(*rateLimited)) //nolint:errcheck // Type is controlled.
+
+		return true
+	})
+}
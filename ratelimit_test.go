@@ -0,0 +1,88 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestConfig_RateLimit(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+		RateLimit: &zapctxd.RateLimitConfig{MessagesPerSecond: 20, BurstSize: 2},
+	})
+
+	ctx := context.Background()
+
+	for i := 0; i < 100; i++ {
+		c.Error(ctx, "flood")
+		c.Important(ctx, "important")
+	}
+
+	c.Warn(ctx, "other")
+
+	assert.Equal(t, 2, strings.Count(w.String(), `"msg":"flood"`))
+	assert.Equal(t, 100, strings.Count(w.String(), `"msg":"important"`))
+	assert.Equal(t, 1, strings.Count(w.String(), `"msg":"other"`))
+
+	w.Reset()
+	time.Sleep(60 * time.Millisecond)
+
+	c.Error(ctx, "flood")
+
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"log rate limit hit","entry_msg":"flood","dropped":98}
+{"level":"error","time":"<stripped>","msg":"flood"}
+`, w.String())
+}
+
+func TestConfig_RateLimit_sync(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+		RateLimit: &zapctxd.RateLimitConfig{MessagesPerSecond: 1},
+	})
+
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		c.Warn(ctx, "flood")
+	}
+
+	require.NoError(t, c.Sync())
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"flood"}
+{"level":"warn","time":"<stripped>","msg":"log rate limit hit","entry_msg":"flood","dropped":9}
+`, w.String())
+
+	c.Warn(ctx, "flood")
+	require.NoError(t, c.Close())
+	assert.Contains(t, w.String(), `"dropped":1}`)
+}
+
+func TestConfig_RateLimit_window(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+		RateLimit: &zapctxd.RateLimitConfig{MessagesPerSecond: 0.1},
+	})
+
+	c.Info(context.Background(), "flood")
+	c.Info(context.Background(), "flood")
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(w.String(), `"msg":"log rate limit hit","entry_msg":"flood","dropped":1}`)
+	}, 3*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,150 @@
+package zapctxd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactFunc transforms value of sensitive field before it is written.
+type RedactFunc func(value any) any
+
+// RedactMask returns a RedactFunc that replaces value with mask.
+func RedactMask(mask string) RedactFunc {
+	return func(_ any) any {
+		return mask
+	}
+}
+
+// RedactHash returns a RedactFunc that replaces value with hex encoded SHA-256 hash of salt and value.
+//
+// Hashes allow correlation of entries with the same value without revealing it.
+func RedactHash(salt string) RedactFunc {
+	return func(value any) any {
+		h := sha256.Sum256([]byte(salt + fmt.Sprint(value)))
+
+		return hex.EncodeToString(h[:])
+	}
+}
+
+// redactValue replaces values of sensitive fields with results of redact functions.
+func redactValue(redact map[string]RedactFunc) func(key string, value any) (any, bool) {
+	return func(key string, value any) (any, bool) {
+		if r, ok := redact[key]; ok {
+			return r(value), true
+		}
+
+		return value, false
+	}
+}
+
+// sanitizeKV returns a processor that replaces values of entry fields with sanitizer.
+func sanitizeKV(sanitize func(key string, value any) (any, bool)) func(e entry) []any {
+	return func(e entry) []any {
+		kv := e.kv
+
+		for i := 0; i < len(kv)-1; i += 2 {
+			k, ok := kv[i].(string)
+			if !ok {
+				continue
+			}
+
+			if v, ok := sanitize(k, kv[i+1]); ok {
+				kv[i+1] = v
+			}
+		}
+
+		return kv
+	}
+}
+
+// withSanitizer returns a copy of logger that replaces values of entry fields and fields attached afterwards.
+func (l *Logger) withSanitizer(sanitize func(key string, value any) (any, bool)) *Logger {
+	nl := *l
+
+	nl.sanitizers = append(l.sanitizers[:len(l.sanitizers):len(l.sanitizers)], sanitize)
+
+	return &nl
+}
+
+// sanitize replaces values of fields attached to logger, as processors of sanitizers do for entry fields.
+//
+// Attached fields are encoded by core once, so they have to be sanitized before core receives them.
+func (l *Logger) sanitize(fields []zap.Field) []zap.Field {
+	if len(l.sanitizers) == 0 {
+		return fields
+	}
+
+	res := fields
+
+	for i, f := range fields {
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+
+		v, ok := enc.Fields[f.Key]
+		if !ok {
+			continue
+		}
+
+		changed := false
+
+		for _, s := range l.sanitizers {
+			if sv, ok := s(f.Key, v); ok {
+				v, changed = sv, true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		if &res[0] == &fields[0] {
+			res = append([]zap.Field(nil), fields...)
+		}
+
+		res[i] = zap.Any(f.Key, v)
+	}
+
+	return res
+}
+
+// piiValue replaces values detected as personally identifiable information with "[PII]".
+func piiValue(detector func(key string, value any) bool) func(key string, value any) (any, bool) {
+	return func(key string, value any) (any, bool) {
+		if detector(key, value) {
+			return "[PII]", true
+		}
+
+		return value, false
+	}
+}
+
+// WithPII returns a logger that replaces values of fields detected as personally identifiable information
+// with "[PII]" and adds "_pii_fields" field with keys of replaced fields.
+//
+// Fields attached with With to the returned logger are replaced too, but not listed in "_pii_fields".
+func (l *Logger) WithPII(detector func(key string, value any) bool) *Logger {
+	pii := piiValue(detector)
+
+	return l.withSanitizer(pii).withProcessor(func(e entry) []any {
+		var keys []string
+
+		for i := 0; i < len(e.kv)-1; i += 2 {
+			k := fmt.Sprint(e.kv[i])
+
+			if v, ok := pii(k, e.kv[i+1]); ok {
+				e.kv[i+1] = v
+				keys = append(keys, k)
+			}
+		}
+
+		if keys == nil {
+			return e.kv
+		}
+
+		return append(e.kv, "_pii_fields", keys)
+	})
+}
@@ -0,0 +1,109 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestConfig_RedactFields(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.DebugLevel,
+		StripTime: true,
+		Output:    w,
+		RedactFields: map[string]zapctxd.RedactFunc{
+			"password": zapctxd.RedactMask("***"),
+			"email":    zapctxd.RedactHash("salt"),
+		},
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "password", "secret")
+
+	c.Debug(ctx, "debug")
+	c.Info(ctx, "info", "email", "john@example.com")
+	c.Important(ctx, "important")
+	c.Warn(ctx, "warn")
+	c.Error(ctx, "error", "password", 12345)
+	c.InfoZ(ctx, "typed", zap.String("email", "john@example.com"))
+
+	assert.Equal(t, `{"level":"debug","time":"<stripped>","msg":"debug","password":"***"}
+{"level":"info","time":"<stripped>","msg":"info","email":"84275df39f6d1786a47398ad2d1fd49333063ed7a398902205210d4f068cfd2c","password":"***"}
+{"level":"info","time":"<stripped>","msg":"important","password":"***"}
+{"level":"warn","time":"<stripped>","msg":"warn","password":"***"}
+{"level":"error","time":"<stripped>","msg":"error","password":"***","password":"***"}
+{"level":"info","time":"<stripped>","msg":"typed","email":"84275df39f6d1786a47398ad2d1fd49333063ed7a398902205210d4f068cfd2c","password":"***"}
+`, w.String())
+}
+
+func TestLogger_WithPII(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithPII(func(key string, value any) bool {
+		if key == "phone" {
+			return true
+		}
+
+		s, ok := value.(string)
+
+		return ok && strings.Contains(s, "@")
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "contact", "john@example.com")
+
+	c.Info(ctx, "registered", "phone", 5551234, "name", "John")
+	c.Info(context.Background(), "no pii", "name", "John")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"registered","phone":"[PII]","name":"John","contact":"[PII]","_pii_fields":["phone","contact"]}
+{"level":"info","time":"<stripped>","msg":"no pii","name":"John"}
+`, w.String())
+}
+
+func TestConfig_RedactFields_with(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:   true,
+		Output:      w,
+		ServiceName: "secret-service",
+		RedactFields: map[string]zapctxd.RedactFunc{
+			"password": zapctxd.RedactMask("***"),
+			"service":  zapctxd.RedactMask("svc"),
+		},
+		FieldEncrypter: encrypterMock{},
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "password", "secret")
+
+	c.With("password", "secret", zap.String("token", "abc"), "name", "John").Info(ctx, "m")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"m","service":"svc","password":"***","token":"enc:hsm-7:QUJD","name":"John","password":"***"}
+`, w.String())
+}
+
+func TestLogger_WithPII_with(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithPII(func(key string, _ any) bool {
+		return key == "phone"
+	})
+
+	c.With("phone", 5551234, "name", "John").Info(context.Background(), "m")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"m","phone":"[PII]","name":"John"}
+`, w.String())
+}
@@ -0,0 +1,44 @@
+package zapctxd
+
+import (
+	"errors"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Reload applies level from configuration to a running logger.
+func (l *Logger) Reload(cfg Config) error {
+	level := zap.InfoLevel
+
+	if cfg.Level != 0 {
+		level = cfg.Level
+	}
+
+	switch le := l.levelEnabler.get().(type) {
+	case zapcore.Core:
+		return errors.New("cannot reload logger created with zap loggers")
+	case zap.AtomicLevel:
+		le.SetLevel(level)
+	default:
+		l.SetLevelEnabler(zap.NewAtomicLevelAt(level))
+	}
+
+	return nil
+}
+
+// Close stops background activities of the logger.
+func (l *Logger) Close() error {
+	var err error
+
+	if l.asyncQueue != nil {
+		l.asyncQueue.close()
+	}
+
+	for _, c := range l.closers {
+		err = multierr.Append(err, c())
+	}
+
+	return err
+}
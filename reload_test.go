@@ -0,0 +1,30 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_Reload(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.WarnLevel,
+		StripTime: true,
+		Output:    w,
+	})
+
+	c.Info(context.Background(), "skipped")
+	require.NoError(t, c.Reload(zapctxd.Config{Level: zap.DebugLevel}))
+	c.Debug(context.Background(), "logged")
+
+	assert.Equal(t, `{"level":"debug","time":"<stripped>","msg":"logged"}
+`, w.String())
+}
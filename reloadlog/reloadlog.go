@@ -0,0 +1,89 @@
+// Package reloadlog reloads configuration of zapctxd.Logger on file changes.
+package reloadlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bool64/zapctxd"
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// Watch reloads level of logger from JSON file at path, e.g. {"level":"debug"}, on file changes.
+//
+// Level is loaded immediately if file exists, failures of later reloads are logged with logger.
+// Watching stops when returned closer is closed.
+func Watch(logger *zapctxd.Logger, path string) (io.Closer, error) {
+	path = filepath.Clean(path)
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// Directory is watched to survive file replacements by editors.
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		return nil, multierr.Append(err, w.Close())
+	}
+
+	if err := reload(logger, path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, multierr.Append(err, w.Close())
+	}
+
+	go func() {
+		ctx := context.Background()
+
+		for {
+			select {
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(ev.Name) != path || !ev.Has(fsnotify.Write|fsnotify.Create) {
+					continue
+				}
+
+				if err := reload(logger, path); err != nil {
+					logger.Error(ctx, "failed to reload log config", "path", path, "error", err)
+				}
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+
+				logger.Error(ctx, "failed to watch log config", "path", path, "error", err)
+			}
+		}
+	}()
+
+	return w, nil
+}
+
+func reload(logger *zapctxd.Logger, path string) error {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is configured by user.
+	if err != nil {
+		return err
+	}
+
+	var fc struct {
+		Level *zapcore.Level `json:"level"`
+	}
+
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+
+	if fc.Level == nil {
+		return nil
+	}
+
+	return logger.Reload(zapctxd.Config{Level: *fc.Level})
+}
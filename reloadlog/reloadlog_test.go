@@ -0,0 +1,80 @@
+package reloadlog_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/reloadlog"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestWatch(t *testing.T) {
+	w := &syncBuffer{}
+	path := filepath.Join(t.TempDir(), "log.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"level":"error"}`), 0o600))
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.InfoLevel,
+		StripTime: true,
+		Output:    w,
+	})
+
+	closer, err := reloadlog.Watch(c, path)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, closer.Close())
+	}()
+
+	c.Info(context.Background(), "skipped")
+	assert.Equal(t, "", w.String())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"level":"debug"}`), 0o600))
+
+	assert.Eventually(t, func() bool {
+		c.Debug(context.Background(), "logged")
+
+		return w.String() != ""
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestWatch_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"level":"unknown"}`), 0o600))
+
+	_, err := reloadlog.Watch(zapctxd.New(zapctxd.Config{}), path)
+	assert.Error(t, err)
+
+	_, err = reloadlog.Watch(zapctxd.New(zapctxd.Config{}), filepath.Join(path, "missing", "log.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package zapctxd
+
+import (
+	"fmt"
+)
+
+// RequireAll returns a logger that checks presence of keys in every entry.
+//
+// Keys can be provided with call-site or context fields. In development mode missing keys cause panic,
+// otherwise an additional warning entry is written.
+func (l *Logger) RequireAll(keys ...string) *Logger {
+	nl := l.withFilter(func(e entry) bool {
+		missing := missingKeys(e.kv, keys)
+		if len(missing) == 0 {
+			return false
+		}
+
+		if l.devMode {
+			panic(fmt.Sprintf("missing required log fields %v in %q", missing, e.msg))
+		}
+
+		l.sugared.Warnw("missing required log fields", "missing_fields", missing, "entry_msg", e.msg)
+
+		return false
+	})
+
+	nl.required = append(l.required[:len(l.required):len(l.required)], keys...)
+
+	return nl
+}
+
+func hasKey(kv []any, key string) bool {
+	for i := 0; i < len(kv); i += 2 {
+		if k, ok := kv[i].(string); ok && k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func missingKeys(kv []any, keys []string) []string {
+	var missing []string
+
+	for _, k := range keys {
+		if !hasKey(kv, k) {
+			missing = append(missing, k)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,46 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_RequireAll(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).RequireAll("user_id", "tenant")
+
+	ctx := ctxd.AddFields(context.Background(), "tenant", "acme")
+
+	c.Info(ctx, "complete", "user_id", 1)
+	c.Info(ctx, "incomplete")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"complete","user_id":1,"tenant":"acme"}
+{"level":"warn","time":"<stripped>","msg":"missing required log fields","missing_fields":["user_id"],"entry_msg":"incomplete"}
+{"level":"info","time":"<stripped>","msg":"incomplete","tenant":"acme"}
+`, w.String())
+}
+
+func TestLogger_RequireAll_dev(t *testing.T) {
+	c := zapctxd.New(zapctxd.Config{
+		DevMode: true,
+		Output:  bytes.NewBuffer(nil),
+	}).RequireAll("user_id")
+
+	assert.NotPanics(t, func() {
+		c.Info(context.Background(), "complete", "user_id", 1)
+	})
+
+	assert.PanicsWithValue(t, `missing required log fields [user_id] in "incomplete"`, func() {
+		c.Info(context.Background(), "incomplete")
+	})
+}
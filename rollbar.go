@@ -0,0 +1,212 @@
+package zapctxd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	rollbarEndpoint  = "https://api.rollbar.com/api/1/item/"
+	rollbarQueueSize = 100
+	rollbarTimeout   = 5 * time.Second
+)
+
+// RollbarOption configures Logger.WithRollbar.
+type RollbarOption func(o *rollbarOptions)
+
+type rollbarOptions struct {
+	environment string
+	minLevel    zapcore.Level
+	endpoint    string
+}
+
+// RollbarEnvironment sets environment of reported items, default "production".
+func RollbarEnvironment(env string) RollbarOption {
+	return func(o *rollbarOptions) {
+		o.environment = env
+	}
+}
+
+// RollbarMinLevel sets minimal level of reported entries, default zap.ErrorLevel.
+func RollbarMinLevel(level zapcore.Level) RollbarOption {
+	return func(o *rollbarOptions) {
+		o.minLevel = level
+	}
+}
+
+// RollbarEndpoint sets URL of Rollbar item API, default "https://api.rollbar.com/api/1/item/".
+func RollbarEndpoint(url string) RollbarOption {
+	return func(o *rollbarOptions) {
+		o.endpoint = url
+	}
+}
+
+// WithRollbar returns a clone of logger that reports entries to Rollbar with access token.
+//
+// Merged fields of entry are sent as custom data, level is mapped to Rollbar severity with
+// panic and fatal entries reported as critical. Items are sent asynchronously, panic and fatal
+// entries are sent synchronously before process terminates.
+// Original logger is not affected, Close of the clone sends queued items and stops reporting.
+func (l *Logger) WithRollbar(token string, opts ...RollbarOption) *Logger {
+	o := rollbarOptions{
+		environment: "production",
+		minLevel:    zap.ErrorLevel,
+		endpoint:    rollbarEndpoint,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	r := &rollbarNotifier{
+		token:  token,
+		opts:   o,
+		client: &http.Client{Timeout: rollbarTimeout},
+		logger: l,
+	}
+
+	r.worker = newAsyncWorker(rollbarQueueSize, r.send, nil)
+	r.worker.metrics = l.metrics
+
+	nl := l.withHook(func(e entry) {
+		if e.level < o.minLevel {
+			return
+		}
+
+		it := r.item(e)
+
+		if e.level > zap.ErrorLevel {
+			r.send(it)
+
+			return
+		}
+
+		_ = r.worker.enqueue(it) //nolint:errcheck // Items are not reported after Close.
+	})
+
+	nl.closers = append(l.closers[:len(l.closers):len(l.closers)], r.Close)
+
+	return nl
+}
+
+type rollbarItem struct {
+	Data rollbarData `json:"data"`
+}
+
+type rollbarData struct {
+	Environment string         `json:"environment"`
+	Level       string         `json:"level"`
+	Timestamp   int64          `json:"timestamp"`
+	Platform    string         `json:"platform"`
+	Language    string         `json:"language"`
+	Body        rollbarBody    `json:"body"`
+	Custom      map[string]any `json:"custom,omitempty"`
+}
+
+type rollbarBody struct {
+	Message struct {
+		Body string `json:"body"`
+	} `json:"message"`
+}
+
+type rollbarNotifier struct {
+	token  string
+	opts   rollbarOptions
+	client *http.Client
+	logger *Logger
+	worker *asyncWorker[rollbarItem]
+}
+
+func rollbarLevel(level zapcore.Level) string {
+	switch {
+	case level <= zap.DebugLevel:
+		return "debug"
+	case level == zap.InfoLevel:
+		return "info"
+	case level == zap.WarnLevel:
+		return "warning"
+	case level == zap.ErrorLevel:
+		return "error"
+	default:
+		return "critical"
+	}
+}
+
+func (r *rollbarNotifier) item(e entry) rollbarItem {
+	it := rollbarItem{Data: rollbarData{
+		Environment: r.opts.environment,
+		Level:       rollbarLevel(e.level),
+		Timestamp:   time.Now().Unix(),
+		Platform:    "go",
+		Language:    "go",
+	}}
+
+	it.Data.Body.Message.Body = e.msg
+
+	if len(e.kv) > 1 {
+		it.Data.Custom = make(map[string]any, len(e.kv)/2)
+
+		for i := 0; i < len(e.kv)-1; i += 2 {
+			it.Data.Custom[fmt.Sprint(e.kv[i])] = e.kv[i+1]
+		}
+	}
+
+	return it
+}
+
+func (r *rollbarNotifier) send(it rollbarItem) {
+	if err := r.post(it); err != nil {
+		r.logger.sugared.Warnw("failed to report to rollbar", "error", err.Error(), "entry_msg", it.Data.Body.Message.Body)
+	}
+}
+
+func (r *rollbarNotifier) post(it rollbarItem) error {
+	body, err := json.Marshal(it)
+	if err != nil {
+		// Custom data is not serializable, sending values as strings.
+		for k, v := range it.Data.Custom {
+			it.Data.Custom[k] = fmt.Sprint(v)
+		}
+
+		if body, err = json.Marshal(it); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, r.opts.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Rollbar-Access-Token", r.token)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Close sends queued items and stops reporting.
+func (r *rollbarNotifier) Close() error {
+	r.worker.close()
+
+	return nil
+}
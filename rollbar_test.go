@@ -0,0 +1,119 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithRollbar(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		items  []map[string]any
+		tokens []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var it struct {
+			Data map[string]any `json:"data"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&it))
+
+		mu.Lock()
+		items = append(items, it.Data)
+		tokens = append(tokens, r.Header.Get("X-Rollbar-Access-Token"))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: w,
+	})
+
+	r := c.WithRollbar("secret",
+		zapctxd.RollbarEndpoint(srv.URL),
+		zapctxd.RollbarEnvironment("staging"),
+		zapctxd.RollbarMinLevel(zap.WarnLevel),
+	)
+
+	ctx := ctxd.AddFields(context.Background(), "foo", 1)
+
+	r.Info(ctx, "skipped")
+	r.Warn(ctx, "slow", "bar", "baz")
+	r.Error(ctx, "failed")
+	c.Error(ctx, "parent is not affected")
+
+	require.NoError(t, r.Close())
+
+	require.Len(t, items, 2)
+	assert.Equal(t, []string{"secret", "secret"}, tokens)
+
+	assert.Equal(t, "staging", items[0]["environment"])
+	assert.Equal(t, "warning", items[0]["level"])
+	assert.Equal(t, map[string]any{"message": map[string]any{"body": "slow"}}, items[0]["body"])
+	assert.Equal(t, map[string]any{"foo": 1.0, "bar": "baz"}, items[0]["custom"])
+
+	assert.Equal(t, "error", items[1]["level"])
+	assert.Equal(t, map[string]any{"message": map[string]any{"body": "failed"}}, items[1]["body"])
+
+	assert.NotContains(t, w.String(), "failed to report to rollbar")
+}
+
+func TestLogger_WithRollbar_failed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithRollbar("invalid", zapctxd.RollbarEndpoint(srv.URL))
+
+	c.Error(context.Background(), "failed")
+	require.NoError(t, c.Close())
+
+	assert.Equal(t, `{"level":"error","time":"<stripped>","msg":"failed"}
+{"level":"warn","time":"<stripped>","msg":"failed to report to rollbar","error":"unexpected response status: 401 Unauthorized","entry_msg":"failed"}
+`, w.String())
+}
+
+func TestLogger_WithRollbar_chained(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		tokens []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.Header.Get("X-Rollbar-Access-Token"))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	}).WithRollbar("first", zapctxd.RollbarEndpoint(srv.URL)).WithRollbar("second", zapctxd.RollbarEndpoint(srv.URL))
+
+	c.Error(context.Background(), "failed")
+
+	// Close of the clone sends queued items of both notifiers.
+	require.NoError(t, c.Close())
+	assert.ElementsMatch(t, []string{"first", "second"}, tokens)
+}
@@ -0,0 +1,79 @@
+package zapctxd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const samplingCacheSize = 10000
+
+// SamplingConfig configures sampling of entries with the same level and message, see zap.SamplingConfig.
+//
+// Every second first Initial entries are written, then each Thereafter entry.
+// Entries of debug context and important entries are not sampled.
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+}
+
+// SampledLogger returns a logger that samples entries by value of key in merged fields,
+// entries with the same value are allowed at ratePerSecond.
+//
+// Entries without key are always written.
+func (l *Logger) SampledLogger(key string, ratePerSecond float64) *Logger {
+	return l.WithSamplingMap(map[string]float64{key: ratePerSecond})
+}
+
+// WithSamplingMap returns a logger that samples entries by values of keys in merged fields,
+// entries with the same value of a key are allowed at rate per second of that key.
+//
+// For example, map[string]float64{"health_check": 0.01, "user_action": 0.1}.
+// Entries without any of keys are always written.
+func (l *Logger) WithSamplingMap(rates map[string]float64) *Logger {
+	keys := make([]string, 0, len(rates))
+
+	for k := range rates {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	buckets := newLRU[*tokenBucket](samplingCacheSize)
+
+	return l.withFilter(func(e entry) bool {
+		now := time.Now()
+
+		for _, k := range keys {
+			v, ok := fieldValue(e.kv, k)
+			if !ok {
+				continue
+			}
+
+			b := buckets.update(k+"\x00"+fmt.Sprint(v), func(b *tokenBucket, found bool) *tokenBucket {
+				if found {
+					return b
+				}
+
+				return &tokenBucket{n: rates[k], period: time.Second}
+			})
+
+			if ok, _ := b.take(now); !ok {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// fieldValue returns value of key in key-value pairs.
+func fieldValue(kv []any, key string) (any, bool) {
+	for i := 0; i < len(kv)-1; i += 2 {
+		if kv[i] == key {
+			return kv[i+1], true
+		}
+	}
+
+	return nil, false
+}
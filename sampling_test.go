@@ -0,0 +1,86 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_SampledLogger(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).SampledLogger("endpoint", 0.01)
+
+	ctx := ctxd.AddFields(context.Background(), "endpoint", "/health")
+
+	for i := 0; i < 5; i++ {
+		c.Info(ctx, "request")
+		c.Info(context.Background(), "request", "endpoint", "/users")
+		c.Info(context.Background(), "unsampled")
+	}
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"request","endpoint":"/health"}
+{"level":"info","time":"<stripped>","msg":"request","endpoint":"/users"}
+{"level":"info","time":"<stripped>","msg":"unsampled"}
+{"level":"info","time":"<stripped>","msg":"unsampled"}
+{"level":"info","time":"<stripped>","msg":"unsampled"}
+{"level":"info","time":"<stripped>","msg":"unsampled"}
+{"level":"info","time":"<stripped>","msg":"unsampled"}
+`, w.String())
+}
+
+func TestLogger_WithSamplingMap(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithSamplingMap(map[string]float64{"health_check": 0.01, "user_action": 3})
+
+	for i := 0; i < 5; i++ {
+		c.Info(context.Background(), "hc", "health_check", true)
+		c.Info(context.Background(), "ua", "user_action", "login")
+	}
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hc","health_check":true}
+{"level":"info","time":"<stripped>","msg":"ua","user_action":"login"}
+{"level":"info","time":"<stripped>","msg":"ua","user_action":"login"}
+{"level":"info","time":"<stripped>","msg":"ua","user_action":"login"}
+`, w.String())
+}
+
+func TestNew_sampling(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output:   w,
+		Sampling: &zapctxd.SamplingConfig{Initial: 10, Thereafter: 100},
+	})
+
+	ctx := context.Background()
+
+	for i := 0; i < 1000; i++ {
+		c.Info(ctx, "hello", "i", i)
+	}
+
+	// First 10 entries and then entries 110, 210, ..., 910.
+	assert.Equal(t, 19, strings.Count(w.String(), "\n"))
+
+	w.Reset()
+
+	for i := 0; i < 1000; i++ {
+		c.Important(ctx, "important")
+		c.Debug(ctxd.WithDebug(ctx), "debug")
+	}
+
+	assert.Equal(t, 2000, strings.Count(w.String(), "\n"))
+}
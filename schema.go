@@ -0,0 +1,82 @@
+package zapctxd
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithSchema returns a logger that checks types of field values.
+//
+// Values of errors are checked as strings. In development mode mismatch causes panic,
+// otherwise an additional warning entry is written. Checks can be toggled with SetSchemaEnabled.
+func (l *Logger) WithSchema(schema map[string]reflect.Type) *Logger {
+	enabled := &atomic.Bool{}
+	enabled.Store(true)
+
+	nl := l.withFilter(func(e entry) bool {
+		if !enabled.Load() {
+			return false
+		}
+
+		for i := 0; i < len(e.kv)-1; i += 2 {
+			k, ok := e.kv[i].(string)
+			if !ok {
+				continue
+			}
+
+			expected, ok := schema[k]
+			if !ok {
+				continue
+			}
+
+			actual := reflect.TypeOf(e.kv[i+1])
+			if actual == expected {
+				continue
+			}
+
+			if l.devMode {
+				panic(fmt.Sprintf("log field %s has type %v, %v expected in %q", k, actual, expected, e.msg))
+			}
+
+			l.sugared.Warnw("log field type mismatch", "field", k,
+				"expected", fmt.Sprint(expected), "actual", fmt.Sprint(actual), "entry_msg", e.msg)
+		}
+
+		return false
+	})
+
+	nl.schemaEnabled = enabled
+
+	return nl
+}
+
+// SetSchemaEnabled toggles checks installed with WithSchema.
+func (l *Logger) SetSchemaEnabled(enabled bool) {
+	if l.schemaEnabled != nil {
+		l.schemaEnabled.Store(enabled)
+	}
+}
+
+// WithConsistencyCheck returns a logger that validates level, message and merged fields of entries with fn.
+//
+// In development mode error of fn causes panic, otherwise an additional warning entry is written
+// with "schema_error" field. Checks are chained when installed multiple times.
+func (l *Logger) WithConsistencyCheck(fn func(level zapcore.Level, msg string, kv []any) error) *Logger {
+	return l.withFilter(func(e entry) bool {
+		err := fn(e.level, e.msg, e.kv)
+		if err == nil {
+			return false
+		}
+
+		if l.devMode {
+			panic(fmt.Sprintf("log consistency check failed for %q: %v", e.msg, err))
+		}
+
+		l.sugared.Warnw("log consistency check failed", "schema_error", err.Error(), "entry_msg", e.msg)
+
+		return false
+	})
+}
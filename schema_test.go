@@ -0,0 +1,93 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithSchema(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	schema := map[string]reflect.Type{"user_id": reflect.TypeOf(int64(0))}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithSchema(schema)
+
+	c.Info(context.Background(), "valid", "user_id", int64(1))
+	c.Info(context.Background(), "invalid", "user_id", "1")
+
+	c.SetSchemaEnabled(false)
+	c.Info(context.Background(), "unchecked", "user_id", "1")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"valid","user_id":1}
+{"level":"warn","time":"<stripped>","msg":"log field type mismatch","field":"user_id","expected":"int64","actual":"string","entry_msg":"invalid"}
+{"level":"info","time":"<stripped>","msg":"invalid","user_id":"1"}
+{"level":"info","time":"<stripped>","msg":"unchecked","user_id":"1"}
+`, w.String())
+
+	dc := zapctxd.New(zapctxd.Config{
+		DevMode: true,
+		Output:  w,
+	}).WithSchema(schema)
+
+	assert.PanicsWithValue(t, `log field user_id has type int, int64 expected in "invalid"`, func() {
+		dc.Info(context.Background(), "invalid", "user_id", 1)
+	})
+}
+
+func TestLogger_WithConsistencyCheck(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	hasUserID := func(_ zapcore.Level, _ string, kv []any) error {
+		for i := 0; i < len(kv); i += 2 {
+			if kv[i] == "user_id" {
+				return nil
+			}
+		}
+
+		return errors.New("missing user_id")
+	}
+
+	noDebug := func(level zapcore.Level, _ string, _ []any) error {
+		if level == zapcore.DebugLevel {
+			return errors.New("debug is not allowed")
+		}
+
+		return nil
+	}
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zapcore.DebugLevel,
+		StripTime: true,
+		Output:    w,
+	}).WithConsistencyCheck(hasUserID).WithConsistencyCheck(noDebug)
+
+	c.Info(context.Background(), "valid", "user_id", 1)
+	c.Info(context.Background(), "invalid")
+	c.Debug(context.Background(), "debug", "user_id", 1)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"valid","user_id":1}
+{"level":"warn","time":"<stripped>","msg":"log consistency check failed","schema_error":"missing user_id","entry_msg":"invalid"}
+{"level":"info","time":"<stripped>","msg":"invalid"}
+{"level":"warn","time":"<stripped>","msg":"log consistency check failed","schema_error":"debug is not allowed","entry_msg":"debug"}
+{"level":"debug","time":"<stripped>","msg":"debug","user_id":1}
+`, w.String())
+
+	dev := zapctxd.New(zapctxd.Config{
+		DevMode: true,
+		Output:  w,
+	}).WithConsistencyCheck(hasUserID)
+
+	assert.PanicsWithValue(t, `log consistency check failed for "invalid": missing user_id`, func() {
+		dev.Info(context.Background(), "invalid")
+	})
+}
@@ -0,0 +1,59 @@
+// Package sentryhook reports log entries to Sentry.
+package sentryhook
+
+import (
+	"github.com/bool64/zapctxd"
+	"github.com/getsentry/sentry-go"
+	"go.uber.org/zap/zapcore"
+)
+
+// SentryHook returns a hook that captures entries with minLevel and above as Sentry events.
+//
+// Entry message is used as event message, fields including context fields are added to event extra data.
+func SentryHook(client *sentry.Client, minLevel zapcore.Level) zapctxd.Hook { //nolint:revive // Name is defined by API.
+	return hook{client: client, minLevel: minLevel}
+}
+
+type hook struct {
+	client   *sentry.Client
+	minLevel zapcore.Level
+}
+
+func (h hook) Fire(level zapcore.Level, msg string, fields []zapcore.Field) error {
+	if level < h.minLevel {
+		return nil
+	}
+
+	event := sentry.NewEvent()
+	event.Level = sentryLevel(level)
+	event.Message = msg
+
+	enc := zapcore.NewMapObjectEncoder()
+
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	if len(enc.Fields) > 0 {
+		event.Extra = enc.Fields
+	}
+
+	h.client.CaptureEvent(event, nil, nil)
+
+	return nil
+}
+
+func sentryLevel(level zapcore.Level) sentry.Level {
+	switch level {
+	case zapcore.DebugLevel:
+		return sentry.LevelDebug
+	case zapcore.InfoLevel:
+		return sentry.LevelInfo
+	case zapcore.WarnLevel:
+		return sentry.LevelWarning
+	case zapcore.ErrorLevel:
+		return sentry.LevelError
+	default:
+		return sentry.LevelFatal
+	}
+}
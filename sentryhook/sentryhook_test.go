@@ -0,0 +1,65 @@
+package sentryhook_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/sentryhook"
+)
+
+type transport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *transport) Flush(_ time.Duration) bool { return true }
+
+func (t *transport) Configure(_ sentry.ClientOptions) {}
+
+func (t *transport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, event)
+}
+
+func TestSentryHook(t *testing.T) {
+	tr := &transport{}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{Transport: tr})
+	require.NoError(t, err)
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: w,
+		Hooks:  []zapctxd.Hook{sentryhook.SentryHook(client, zap.ErrorLevel)},
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "user", "john")
+
+	c.Warn(ctx, "warning", "foo", "bar")
+	c.Error(ctx, "failed to process", "order", 123, "error", ctxd.WrapError(ctx, errors.New("timeout"), "processing"))
+
+	assert.Contains(t, w.String(), `"msg":"failed to process"`)
+
+	require.Len(t, tr.events, 1)
+
+	e := tr.events[0]
+	assert.Equal(t, sentry.LevelError, e.Level)
+	assert.Equal(t, "failed to process", e.Message)
+	assert.Equal(t, "john", e.Extra["user"])
+	assert.Equal(t, int64(123), e.Extra["order"])
+	assert.Equal(t, "processing: timeout", e.Extra["error"])
+}
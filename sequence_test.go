@@ -0,0 +1,65 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestConfig_AddSequenceNumber(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:         true,
+		Output:            w,
+		AddSequenceNumber: true,
+	})
+
+	ctx := context.Background()
+
+	c.Info(ctx, "hello", "foo", 1)
+	c.Warn(ctx, "hello")
+
+	cl := c.Clone()
+	cl.Info(ctx, "cloned")
+	c.Info(ctx, "original")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","foo":1,"seq":1}
+{"level":"warn","time":"<stripped>","msg":"hello","seq":2}
+{"level":"info","time":"<stripped>","msg":"cloned","seq":1}
+{"level":"info","time":"<stripped>","msg":"original","seq":3}
+`, w.String())
+}
+
+func TestLogger_Reset(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime:         true,
+		Output:            w,
+		AddSequenceNumber: true,
+	})
+
+	ctx := context.Background()
+	child := c.With("foo", 1)
+
+	c.Info(ctx, "first")
+	child.Info(ctx, "second")
+
+	c.Reset()
+
+	child.Info(ctx, "reset")
+	c.Info(ctx, "next")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"first","seq":1}
+{"level":"info","time":"<stripped>","msg":"second","foo":1,"seq":2}
+{"level":"info","time":"<stripped>","msg":"reset","foo":1,"seq":1}
+{"level":"info","time":"<stripped>","msg":"next","seq":2}
+`, w.String())
+
+	zapctxd.New(zapctxd.Config{}).Reset()
+}
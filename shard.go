@@ -0,0 +1,159 @@
+package zapctxd
+
+import (
+	"io"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// shardBuffer is a number of writes buffered by each shard.
+const shardBuffer = 1024
+
+// shards distributes entries between asynchronous writers.
+type shards struct {
+	writers []*AsyncWriter
+	fn      func(msg string, kv []any) int
+}
+
+func (s *shards) close() error {
+	var err error
+
+	for _, w := range s.writers {
+		err = multierr.Append(err, w.Close())
+	}
+
+	return err
+}
+
+// core creates a core that writes entries to shard writers with encoder.
+func (s *shards) core(enc zapcore.Encoder, enab zapcore.LevelEnabler) zapcore.Core {
+	cores := make([]zapcore.Core, len(s.writers))
+
+	for i, w := range s.writers {
+		cores[i] = newCore(enc.Clone(), w, enab)
+	}
+
+	return shardCore{Core: cores[0], cores: cores, fn: s.fn}
+}
+
+// WithSharding returns a clone of logger that distributes entries between numShards writers.
+//
+// Each shard has its own buffer and goroutine that writes to logger output, so output must support
+// concurrent writes. Shard of entry is determined by shardFn with message and fields of entry,
+// e.g. by a hash of request ID, result is taken modulo numShards.
+//
+// Writes block when buffer of shard is full, entries are not dropped.
+// Sync flushes all shards, Close stops them. Logger created with zap loggers is returned unchanged.
+func (l *Logger) WithSharding(numShards int, shardFn func(msg string, kv []any) int) *Logger {
+	if l.mu == nil || numShards < 1 {
+		return l
+	}
+
+	l.mu.RLock()
+	// Output is wrapped to hide io.Closer, it is not owned by shard.
+	out := unownedOutput{l.out}
+	l.mu.RUnlock()
+
+	outputs := make([]io.Writer, numShards)
+	for i := range outputs {
+		outputs[i] = out
+	}
+
+	return l.WithShardOutputs(shardFn, outputs...)
+}
+
+// WithShardOutputs returns a clone of logger that distributes entries between outputs, one shard per output.
+//
+// It works as WithSharding, but each shard writes to its own output, result of shardFn is taken
+// modulo number of outputs. Outputs that implement io.Closer are closed by Close.
+func (l *Logger) WithShardOutputs(shardFn func(msg string, kv []any) int, outputs ...io.Writer) *Logger {
+	if l.mu == nil || len(outputs) == 0 {
+		return l
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	s := &shards{fn: shardFn}
+
+	for _, out := range outputs {
+		w := NewAsyncWriter(out, shardBuffer)
+		w.block = true
+		w.metrics = l.metrics
+
+		s.writers = append(s.writers, w)
+	}
+
+	nl := *l
+	nl.mu = &sync.RWMutex{}
+	nl.shards = s
+	nl.closers = []func() error{s.close}
+
+	nl.make()
+
+	return &nl
+}
+
+// shardCore passes entries to one of cores chosen by fields.
+type shardCore struct {
+	zapcore.Core
+
+	cores  []zapcore.Core
+	fields []zapcore.Field
+	fn     func(msg string, kv []any) int
+}
+
+func (c shardCore) With(fields []zapcore.Field) zapcore.Core {
+	cores := make([]zapcore.Core, len(c.cores))
+
+	for i, sc := range c.cores {
+		cores[i] = sc.With(fields)
+	}
+
+	return shardCore{
+		Core:   cores[0],
+		cores:  cores,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+		fn:     c.fn,
+	}
+}
+
+func (c shardCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+func (c shardCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	kv := fieldsKV(append(c.fields[:len(c.fields):len(c.fields)], fields...))
+
+	i := c.fn(ent.Message, kv) % len(c.cores)
+	if i < 0 {
+		i += len(c.cores)
+	}
+
+	return c.cores[i].Write(ent, fields)
+}
+
+func (c shardCore) Sync() error {
+	var err error
+
+	for _, sc := range c.cores {
+		err = multierr.Append(err, sc.Sync())
+	}
+
+	return err
+}
+
+// newShardedCore returns core of logger output, or core of shards if sharding is enabled.
+func (l *Logger) newShardedCore(enab zapcore.LevelEnabler) zapcore.Core {
+	if l.shards != nil {
+		return l.shards.core(l.encoder, enab)
+	}
+
+	return newCore(l.encoder, l.out, enab)
+}
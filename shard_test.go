@@ -0,0 +1,108 @@
+package zapctxd_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithSharding(t *testing.T) {
+	w := &syncBuffer{}
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).With("app", "test").WithSharding(4, func(msg string, kv []any) int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var id string
+
+		for i := 0; i < len(kv)-1; i += 2 {
+			if kv[i] == "request_id" {
+				id = kv[i+1].(string)
+			}
+		}
+
+		calls = append(calls, msg+" "+id)
+
+		return len(id) - 10
+	})
+
+	var wg sync.WaitGroup
+
+	for _, id := range []string{"a", "bb", "ccc", "dddd", "eeeee"} {
+		wg.Add(1)
+
+		go func(id string) {
+			defer wg.Done()
+
+			c.Info(ctxd.AddFields(context.Background(), "request_id", id), "hello")
+		}(id)
+	}
+
+	wg.Wait()
+	require.NoError(t, c.Sync())
+
+	assert.Len(t, calls, 5)
+	assert.Contains(t, calls, "hello ccc")
+	assert.Equal(t, 5, strings.Count(w.String(), `"msg":"hello","app":"test","request_id":"`))
+
+	require.NoError(t, c.Close())
+}
+
+func TestLogger_WithShardOutputs(t *testing.T) {
+	outputs := []*syncBuffer{{}, {}}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+	}).WithShardOutputs(func(msg string, _ []any) int {
+		return len(msg)
+	}, outputs[0], outputs[1])
+
+	for i := 0; i < 2000; i++ {
+		c.Info(context.Background(), "even")
+		c.Info(context.Background(), "odd")
+	}
+
+	require.NoError(t, c.Sync())
+
+	assert.Equal(t, 2000, strings.Count(outputs[0].String(), `"msg":"even"`))
+	assert.Equal(t, 2000, strings.Count(outputs[1].String(), `"msg":"odd"`))
+	assert.Equal(t, 2000, strings.Count(outputs[1].String(), "\n"))
+	assert.Equal(t, int64(0), c.Metrics().DroppedEntries)
+
+	require.NoError(t, c.Close())
+}
+
+func TestLogger_WithSharding_outputs(t *testing.T) {
+	warn := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.DebugLevel,
+		StripTime: true,
+		Outputs:   []zapctxd.OutputSpec{{Writer: warn, MinLevel: zap.WarnLevel}},
+	}).WithSharding(2, func(msg string, _ []any) int {
+		return len(msg)
+	})
+
+	c.Debug(context.Background(), "debug")
+	c.Warn(context.Background(), "warning")
+
+	require.NoError(t, c.Sync())
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"warning"}`+"\n", warn.String())
+	require.NoError(t, c.Close())
+}
@@ -0,0 +1,132 @@
+//go:build go1.21
+
+package zapctxd
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SlogHandler returns slog.Handler that writes records with logger.
+//
+// Attributes of groups are prefixed with dot-separated group names.
+// Handler is available with Go 1.21 or later.
+func (l *Logger) SlogHandler() slog.Handler { //nolint:ireturn // Handler is a standard interface.
+	return slogHandler{l: l}
+}
+
+type slogHandler struct {
+	l      *Logger
+	prefix string
+}
+
+func slogLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zap.DebugLevel
+	case level < slog.LevelWarn:
+		return zap.InfoLevel
+	case level < slog.LevelError:
+		return zap.WarnLevel
+	default:
+		return zap.ErrorLevel
+	}
+}
+
+func (h slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	_, ok := h.l.enabled(ctx, slogLevel(level))
+
+	return ok
+}
+
+func (h slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	kv := make([]any, 0, 2*r.NumAttrs())
+
+	r.Attrs(func(a slog.Attr) bool {
+		kv = appendSlogAttr(kv, h.prefix, a)
+
+		return true
+	})
+
+	l := h.logger(r.PC)
+
+	switch slogLevel(r.Level) {
+	case zap.DebugLevel:
+		l.Debug(ctx, r.Message, kv...)
+	case zap.InfoLevel:
+		l.Info(ctx, r.Message, kv...)
+	case zap.WarnLevel:
+		l.Warn(ctx, r.Message, kv...)
+	default:
+		l.Error(ctx, r.Message, kv...)
+	}
+
+	return nil
+}
+
+// logger returns logger that reports caller of record instead of handler.
+//
+// Frames between Handle and caller depend on slog function used, so they are counted by program counter of record.
+func (h slogHandler) logger(pc uintptr) *Logger {
+	if pc == 0 || !h.l.caller {
+		return h.l
+	}
+
+	var pcs [32]uintptr
+
+	// Skipping runtime.Callers, logger and Handle.
+	n := runtime.Callers(3, pcs[:])
+
+	for i, p := range pcs[:n] {
+		if p == pc {
+			return h.l.WithCaller(i + 1)
+		}
+	}
+
+	return h.l
+}
+
+func (h slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler { //nolint:ireturn // Handler is a standard interface.
+	kv := make([]any, 0, 2*len(attrs))
+
+	for _, a := range attrs {
+		kv = appendSlogAttr(kv, h.prefix, a)
+	}
+
+	return slogHandler{l: h.l.With(kv...), prefix: h.prefix}
+}
+
+func (h slogHandler) WithGroup(name string) slog.Handler { //nolint:ireturn // Handler is a standard interface.
+	if name == "" {
+		return h
+	}
+
+	return slogHandler{l: h.l, prefix: h.prefix + name + "."}
+}
+
+// appendSlogAttr appends key-value pairs of attribute with flattened groups.
+func appendSlogAttr(kv []any, prefix string, a slog.Attr) []any {
+	a.Value = a.Value.Resolve()
+
+	if a.Equal(slog.Attr{}) {
+		return kv
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+
+		for _, ga := range a.Value.Group() {
+			kv = appendSlogAttr(kv, prefix, ga)
+		}
+
+		return kv
+	}
+
+	return append(kv, prefix+a.Key, a.Value.Any())
+}
@@ -0,0 +1,78 @@
+//go:build go1.21
+
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_SlogHandler(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	ctx := ctxd.AddFields(context.Background(), "foo", 1)
+
+	c.Info(ctx, "hello", "bar", "baz", "elapsed", time.Second)
+	direct := w.String()
+
+	w.Reset()
+
+	s := slog.New(c.SlogHandler())
+	s.InfoContext(ctx, "hello", "bar", "baz", "elapsed", time.Second)
+	assert.Equal(t, direct, w.String())
+
+	w.Reset()
+
+	s.Debug("skipped")
+	s.With("service", "api").WithGroup("req").With("id", 1).
+		WarnContext(ctx, "slow", slog.Group("db", "rows", 10), "sql", "SELECT 1")
+	s.Error("failed", slog.Group("", "inline", true), "err", assert.AnError)
+
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"slow","service":"api","req.id":1,"req.db.rows":10,"req.sql":"SELECT 1","foo":1}
+{"level":"error","time":"<stripped>","msg":"failed","inline":true,"err":"assert.AnError general error for testing"}
+`, w.String())
+
+	assert.False(t, c.SlogHandler().Enabled(context.Background(), slog.LevelDebug))
+	assert.True(t, c.SlogHandler().Enabled(ctxd.WithDebug(context.Background()), slog.LevelDebug))
+
+	c.SetLevelEnabler(zap.DebugLevel)
+	assert.True(t, c.SlogHandler().Enabled(context.Background(), slog.LevelDebug))
+}
+
+func TestLogger_SlogHandler_caller(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithCaller(0)
+
+	s := slog.New(c.SlogHandler())
+
+	_, _, line, _ := runtime.Caller(0)
+
+	s.InfoContext(context.Background(), "hello")
+	slog.New(c.SlogHandler().WithAttrs([]slog.Attr{slog.Int("foo", 1)})).Warn("attrs")
+	s.Log(context.Background(), slog.LevelError, "log")
+
+	assert.Equal(t, fmt.Sprintf(`{"level":"info","time":"<stripped>","caller":"zapctxd/slog_test.go:%d","msg":"hello"}
+{"level":"warn","time":"<stripped>","caller":"zapctxd/slog_test.go:%d","msg":"attrs","foo":1}
+{"level":"error","time":"<stripped>","caller":"zapctxd/slog_test.go:%d","msg":"log"}
+`, line+2, line+3, line+4), w.String())
+}
@@ -0,0 +1,87 @@
+package zapctxd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ExposeStatus registers operational endpoints of logger in mux.
+//
+//   - GET, PUT {path}/level to inspect and change level, e.g. {"level":"debug"},
+//   - GET {path}/health for health check,
+//   - GET {path}/metrics to get LoggerMetrics,
+//   - GET {path}/snapshot to get LoggerSnapshot.
+func (l *Logger) ExposeStatus(mux *http.ServeMux, path string) {
+	mux.HandleFunc(path+"/level", l.serveLevel)
+	mux.HandleFunc(path+"/health", func(rw http.ResponseWriter, r *http.Request) {
+		if !allowMethods(rw, r, http.MethodGet) {
+			return
+		}
+
+		writeJSON(rw, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc(path+"/metrics", func(rw http.ResponseWriter, r *http.Request) {
+		if !allowMethods(rw, r, http.MethodGet) {
+			return
+		}
+
+		writeJSON(rw, http.StatusOK, l.Metrics())
+	})
+	mux.HandleFunc(path+"/snapshot", func(rw http.ResponseWriter, r *http.Request) {
+		if !allowMethods(rw, r, http.MethodGet) {
+			return
+		}
+
+		writeJSON(rw, http.StatusOK, l.Snapshot())
+	})
+}
+
+type levelPayload struct {
+	Level *zapcore.Level `json:"level"`
+}
+
+func (l *Logger) serveLevel(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethods(rw, r, http.MethodGet, http.MethodPut) {
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		var p levelPayload
+
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil || p.Level == nil {
+			writeJSON(rw, http.StatusBadRequest, map[string]string{"error": "level is required, e.g. {\"level\":\"debug\"}"})
+
+			return
+		}
+
+		if err := l.Reload(Config{Level: *p.Level}); err != nil {
+			writeJSON(rw, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+
+			return
+		}
+	}
+
+	lvl := zapcore.LevelOf(l.levelEnabler.get())
+	writeJSON(rw, http.StatusOK, levelPayload{Level: &lvl})
+}
+
+func allowMethods(rw http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, m := range methods {
+		if r.Method == m {
+			return true
+		}
+	}
+
+	writeJSON(rw, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+
+	return false
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v any) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+
+	_ = json.NewEncoder(rw).Encode(v) //nolint:errchkjson // Best effort response.
+}
@@ -0,0 +1,80 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_ExposeStatus(t *testing.T) {
+	c := zapctxd.New(zapctxd.Config{
+		Level:  zap.WarnLevel,
+		Output: bytes.NewBuffer(nil),
+	})
+
+	mux := http.NewServeMux()
+	c.ExposeStatus(mux, "/log")
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	call := func(method, path, body string) (int, []byte) {
+		t.Helper()
+
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, b
+	}
+
+	status, body := call(http.MethodGet, "/log/level", "")
+	assert.Equal(t, http.StatusOK, status)
+	assertjson.Equal(t, []byte(`{"level":"warn"}`), body)
+
+	status, body = call(http.MethodPut, "/log/level", `{"level":"debug"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assertjson.Equal(t, []byte(`{"level":"debug"}`), body)
+
+	status, _ = call(http.MethodPut, "/log/level", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, body = call(http.MethodGet, "/log/health", "")
+	assert.Equal(t, http.StatusOK, status)
+	assertjson.Equal(t, []byte(`{"status":"ok"}`), body)
+
+	status, _ = call(http.MethodPost, "/log/health", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+
+	c.Debug(context.Background(), "hello")
+	c.Error(context.Background(), "hello")
+	c.Error(context.Background(), "hello")
+
+	status, body = call(http.MethodGet, "/log/metrics", "")
+	assert.Equal(t, http.StatusOK, status)
+	assertjson.Equal(t, []byte(`{"entries":{"debug":1,"info":0,"warn":0,"error":2,"dpanic":0,"panic":0,"fatal":0},"dropped_entries":0}`), body)
+
+	status, body = call(http.MethodGet, "/log/snapshot", "")
+	assert.Equal(t, http.StatusOK, status)
+	assertjson.Equal(t, []byte(`{"level":"debug","dev_mode":false,"metrics":{"entries":"<ignore-diff>","dropped_entries":0}}`), body)
+}
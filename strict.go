@@ -0,0 +1,126 @@
+package zapctxd
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/bool64/ctxd"
+	"go.uber.org/zap"
+)
+
+// StrictMode returns a copy of logger that panics on misuse.
+//
+// Logger panics on nil context, odd number of key-value pairs, non-string keys and empty message,
+// panic message contains merged key-value pairs. It is intended to surface programming errors in staging.
+func (l *Logger) StrictMode() *Logger {
+	nl := *l
+
+	nl.strict = true
+
+	return &nl
+}
+
+func (l *Logger) checkStrict(ctx context.Context, msg string, keysAndValues []any) {
+	kv := append(make([]any, 0, len(keysAndValues)), keysAndValues...)
+
+	if ctx == nil {
+		panic(fmt.Sprintf("nil context in log entry %q %v", msg, kv))
+	}
+
+	kv = append(kv, ctxd.Fields(ctx)...)
+
+	if msg == "" {
+		panic(fmt.Sprintf("empty message in log entry %v", kv))
+	}
+
+	if len(kv)%2 != 0 {
+		panic(fmt.Sprintf("odd number of key-value pairs in log entry %q %v", msg, kv))
+	}
+
+	for i := 0; i < len(kv); i += 2 {
+		if _, ok := kv[i].(string); !ok {
+			panic(fmt.Sprintf("non-string key %v in log entry %q %v", kv[i], msg, kv))
+		}
+	}
+}
+
+// checkOddKV reports call-site key-value pairs with odd number of elements, zap fields and prioritized pairs
+// are not counted.
+//
+// Development mode logger panics, otherwise a warning with caller location is written.
+func (l *Logger) checkOddKV(msg string, keysAndValues []any) {
+	n := 0
+
+	for _, v := range keysAndValues {
+		switch v.(type) {
+		case zap.Field, PriorityField:
+		default:
+			n++
+		}
+	}
+
+	if n%2 == 0 {
+		return
+	}
+
+	caller := callerLocation()
+
+	if l.devMode {
+		panic(fmt.Sprintf("odd number of key-value pairs in log entry %q at %s: %d elements", msg, caller, n))
+	}
+
+	l.sugared.Warnw("odd number of key-value pairs in log entry", "caller", caller, "entry_msg", msg, "count", n)
+}
+
+// callerLocation returns file and line of the first caller outside of this package.
+func callerLocation() string {
+	var pcs [32]uintptr
+
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+
+	for {
+		f, more := frames.Next()
+
+		if !strings.HasPrefix(f.Function, packagePrefix) {
+			return f.File + ":" + strconv.Itoa(f.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// normalizeKeys replaces non-string keys of key-value pairs with their string representation,
+// zap fields are skipped.
+//
+// Development mode logger writes a warning for every replaced key.
+func (l *Logger) normalizeKeys(msg string, kv []any) []any {
+	copied := false
+
+	for i := 0; i < len(kv)-1; i++ {
+		if _, ok := kv[i].(zap.Field); ok {
+			continue
+		}
+
+		if _, ok := kv[i].(string); !ok {
+			if !copied {
+				kv = append(make([]any, 0, len(kv)), kv...)
+				copied = true
+			}
+
+			kv[i] = fmt.Sprintf("%v", kv[i])
+
+			if l.devMode {
+				l.sugared.Warnw("non-string key in log entry", "caller", callerLocation(), "key", kv[i], "entry_msg", msg)
+			}
+		}
+
+		i++
+	}
+
+	return kv
+}
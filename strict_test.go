@@ -0,0 +1,85 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_StrictMode(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).StrictMode()
+
+	ctx := ctxd.AddFields(context.Background(), "user", "John")
+
+	c.Info(ctx, "fine", "foo", "bar")
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"fine","foo":"bar","user":"John"}
+`, w.String())
+
+	assert.PanicsWithValue(t, `odd number of key-value pairs in log entry "odd" [foo user John]`, func() {
+		c.Info(ctx, "odd", "foo")
+	})
+
+	assert.PanicsWithValue(t, `non-string key 1 in log entry "key" [1 bar user John]`, func() {
+		c.Debug(ctx, "key", 1, "bar")
+	})
+
+	assert.PanicsWithValue(t, `empty message in log entry [user John]`, func() {
+		c.Error(ctx, "")
+	})
+
+	assert.PanicsWithValue(t, `nil context in log entry "nil" [foo bar]`, func() {
+		c.Warn(nil, "nil", "foo", "bar") //nolint:staticcheck // Testing nil context.
+	})
+}
+
+type stringerKey struct{}
+
+func (stringerKey) String() string {
+	return "stringer"
+}
+
+func TestLogger_nonStringKeys(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	ctx := ctxd.AddFields(context.Background(), 3, "ctx")
+	kv := []any{1, "int", stringerKey{}, "stringer", struct{}{}, "struct"}
+
+	c.Info(ctx, "hello", kv...)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","1":"int","stringer":"stringer","{}":"struct","3":"ctx"}
+`, w.String())
+	assert.Equal(t, 1, kv[0], "arguments must not be changed")
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		DevMode:   true,
+		Output:    w,
+	})
+
+	_, file, line, _ := runtime.Caller(0)
+
+	c.Info(context.Background(), "hello", 1, "int")
+
+	assert.Contains(t, w.String(), fmt.Sprintf("\tnon-string key in log entry\t"+
+		`{"caller": "%s:%d", "key": "1", "entry_msg": "hello"}`+"\n", file, line+2))
+	assert.Contains(t, w.String(), fmt.Sprintf("\tzapctxd/strict_test.go:%d\thello\t{\"1\": \"int\"}\n", line+2))
+}
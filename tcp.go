@@ -0,0 +1,104 @@
+package zapctxd
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	tcpQueueSize     = 10000
+	tcpDialTimeout   = 5 * time.Second
+	tcpMinBackoff    = 100 * time.Millisecond
+	tcpMaxBackoff    = 30 * time.Second
+	tcpBackoffFactor = 2
+)
+
+// WithTCPOutput returns a clone of logger that ships entries to a TCP listener, e.g. Logstash.
+//
+// Writes are asynchronous, connection is reestablished with exponential backoff on failures.
+// Original logger is not affected, Close of the clone closes the connection.
+func (l *Logger) WithTCPOutput(addr string) (*Logger, error) {
+	conn, err := net.DialTimeout("tcp", addr, tcpDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	aw := NewAsyncWriter(&reconnectWriter{network: "tcp", addr: addr, conn: conn}, tcpQueueSize)
+
+	return l.withOutput(aw, aw.Close)
+}
+
+// reconnectWriter writes to a network connection and redials it after failures.
+type reconnectWriter struct {
+	network string
+	addr    string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	backoff time.Duration
+	retryAt time.Time
+}
+
+func (w *reconnectWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		if time.Now().Before(w.retryAt) {
+			return 0, fmt.Errorf("waiting to reconnect to %s", w.addr)
+		}
+
+		conn, err := net.DialTimeout(w.network, w.addr, tcpDialTimeout)
+		if err != nil {
+			w.fail()
+
+			return 0, err
+		}
+
+		w.conn = conn
+	}
+
+	n, err := w.conn.Write(p)
+	if err != nil {
+		_ = w.conn.Close() //nolint:errcheck // Connection is already broken.
+		w.conn = nil
+		w.fail()
+
+		return n, err
+	}
+
+	w.backoff = 0
+
+	return n, nil
+}
+
+func (w *reconnectWriter) fail() {
+	switch {
+	case w.backoff == 0:
+		w.backoff = tcpMinBackoff
+	case w.backoff < tcpMaxBackoff:
+		w.backoff *= tcpBackoffFactor
+	}
+
+	if w.backoff > tcpMaxBackoff {
+		w.backoff = tcpMaxBackoff
+	}
+
+	w.retryAt = time.Now().Add(w.backoff)
+}
+
+func (w *reconnectWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+
+	return err
+}
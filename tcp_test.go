@@ -0,0 +1,62 @@
+package zapctxd_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithTCPOutput(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	lines := make(chan string, 10)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			s := bufio.NewScanner(conn)
+			for s.Scan() {
+				lines <- s.Text()
+			}
+
+			_ = conn.Close()
+		}
+	}()
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    bytes.NewBuffer(nil),
+	})
+
+	tc, err := c.WithTCPOutput(ln.Addr().String())
+	require.NoError(t, err)
+
+	tc.Info(context.Background(), "hello", "foo", 1)
+	require.NoError(t, tc.ZapLogger().Sync())
+
+	select {
+	case line := <-lines:
+		assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","foo":1}`, line)
+	case <-time.After(time.Second):
+		t.Fatal("line not received")
+	}
+
+	require.NoError(t, tc.Close())
+	require.NoError(t, ln.Close())
+
+	_, err = c.WithTCPOutput(ln.Addr().String())
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package zapctxd
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TelemetryProvider records written log entries, it can be bridged to any observability backend.
+type TelemetryProvider interface {
+	// RecordLog is called after entry is written, duration is a time taken to encode and write entry.
+	RecordLog(level zapcore.Level, msg string, duration time.Duration)
+}
+
+// WithTelemetry returns a logger that reports written entries to telemetry provider.
+func (l *Logger) WithTelemetry(tp TelemetryProvider) *Logger {
+	nl := *l
+
+	opt := zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return telemetryCore{Core: c, tp: tp}
+	})
+
+	nl.options = append(l.options[:len(l.options):len(l.options)], opt)
+	nl.zl = l.zl.WithOptions(opt)
+	nl.zdebug = l.zdebug.WithOptions(opt)
+	nl.sugared = nl.zl.Sugar()
+	nl.debug = nl.zdebug.Sugar()
+
+	return &nl
+}
+
+// telemetryCore measures duration of underlying core writes.
+type telemetryCore struct {
+	zapcore.Core
+
+	tp TelemetryProvider
+}
+
+func (c telemetryCore) With(fields []zapcore.Field) zapcore.Core {
+	return telemetryCore{Core: c.Core.With(fields), tp: c.tp}
+}
+
+func (c telemetryCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkWrapper(c.Core, c, ent, ce)
+}
+
+func (c telemetryCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	start := time.Now()
+	err := c.Core.Write(ent, fields)
+
+	c.tp.RecordLog(ent.Level, ent.Message, time.Since(start))
+
+	return err
+}
@@ -0,0 +1,66 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+type telemetryRecord struct {
+	level    zapcore.Level
+	msg      string
+	duration time.Duration
+}
+
+type telemetryProvider struct {
+	records []telemetryRecord
+}
+
+func (p *telemetryProvider) RecordLog(level zapcore.Level, msg string, duration time.Duration) {
+	p.records = append(p.records, telemetryRecord{level: level, msg: msg, duration: duration})
+}
+
+func TestLogger_WithTelemetry(t *testing.T) {
+	tp := &telemetryProvider{}
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: &slowWriter{delay: 10 * time.Millisecond},
+	}).WithTelemetry(tp)
+
+	c.Debug(context.Background(), "skipped")
+	c.Info(context.Background(), "hello")
+	c.Warn(ctxd.AddFields(context.Background(), "foo", 1), "slow")
+
+	require.Len(t, tp.records, 2)
+	assert.Equal(t, zapcore.InfoLevel, tp.records[0].level)
+	assert.Equal(t, "hello", tp.records[0].msg)
+	assert.Equal(t, zapcore.WarnLevel, tp.records[1].level)
+	assert.Equal(t, "slow", tp.records[1].msg)
+	assert.GreaterOrEqual(t, tp.records[1].duration, 10*time.Millisecond)
+}
+
+func TestLogger_WithTelemetry_sampling(t *testing.T) {
+	tp := &telemetryProvider{}
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output:   w,
+		Sampling: &zapctxd.SamplingConfig{Initial: 1, Thereafter: 100},
+	}).WithTelemetry(tp)
+
+	for i := 0; i < 100; i++ {
+		c.Info(context.Background(), "hello")
+	}
+
+	assert.Equal(t, 1, strings.Count(w.String(), "\n"))
+	assert.Len(t, tp.records, 1)
+}
@@ -0,0 +1,88 @@
+package zapctxd
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrWriteTimeout is returned when write to output does not complete in time.
+var ErrWriteTimeout = errors.New("write timeout")
+
+type writeResult struct {
+	n   int
+	err error
+}
+
+// timeoutWriter limits duration of writes to underlying writer, pending writes are serialized.
+type timeoutWriter struct {
+	ws      zapcore.WriteSyncer
+	timeout time.Duration
+	sem     chan struct{}
+}
+
+func (w *timeoutWriter) do(fn func() writeResult) writeResult {
+	t := time.NewTimer(w.timeout)
+	defer t.Stop()
+
+	select {
+	case w.sem <- struct{}{}:
+	case <-t.C:
+		return writeResult{err: ErrWriteTimeout}
+	}
+
+	done := make(chan writeResult, 1)
+
+	go func() {
+		defer func() { <-w.sem }()
+
+		done <- fn()
+	}()
+
+	select {
+	case r := <-done:
+		return r
+	case <-t.C:
+		return writeResult{err: ErrWriteTimeout}
+	}
+}
+
+func (w *timeoutWriter) Write(p []byte) (int, error) {
+	p = append([]byte(nil), p...)
+
+	r := w.do(func() writeResult {
+		n, err := w.ws.Write(p)
+
+		return writeResult{n: n, err: err}
+	})
+
+	return r.n, r.err
+}
+
+func (w *timeoutWriter) WriteLevel(level zapcore.Level, p []byte) error {
+	p = append([]byte(nil), p...)
+
+	return w.do(func() writeResult {
+		return writeResult{err: writeLevel(w.ws, level, p)}
+	}).err
+}
+
+func (w *timeoutWriter) Sync() error {
+	return w.do(func() writeResult {
+		return writeResult{err: w.ws.Sync()}
+	}).err
+}
+
+// WithRequestTimeout returns a clone of logger that limits every write and sync of output to timeout.
+//
+// Timed out operation is abandoned and fails with ErrWriteTimeout, that is reported to zap error output,
+// see zap.ErrorOutput. Logger created with zap loggers is returned unchanged.
+func (l *Logger) WithRequestTimeout(timeout time.Duration) *Logger {
+	nl, err := l.withOutput(&timeoutWriter{ws: l.out, timeout: timeout, sem: make(chan struct{}, 1)}, nil)
+	if err != nil {
+		return l
+	}
+
+	return nl
+}
@@ -0,0 +1,77 @@
+package zapctxd_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bool64/zapctxd"
+)
+
+type slowWriter struct {
+	syncBuffer
+	delay time.Duration
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+
+	return w.syncBuffer.Write(p)
+}
+
+func TestLogger_WithRequestTimeout(t *testing.T) {
+	w := &slowWriter{delay: 50 * time.Millisecond}
+	errOut := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}, zap.ErrorOutput(zapcore.AddSync(errOut))).WithRequestTimeout(10 * time.Millisecond)
+
+	start := time.Now()
+
+	c.Info(context.Background(), "slow")
+
+	assert.Less(t, time.Since(start), 40*time.Millisecond)
+	assert.Contains(t, errOut.String(), "write timeout")
+
+	// Abandoned write completes in background.
+	assert.Eventually(t, func() bool {
+		return w.String() == `{"level":"info","time":"<stripped>","msg":"slow"}`+"\n"
+	}, time.Second, 10*time.Millisecond)
+
+	w = &slowWriter{}
+	errOut = &syncBuffer{}
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}, zap.ErrorOutput(zapcore.AddSync(errOut))).WithRequestTimeout(time.Second)
+
+	c.Info(context.Background(), "fast")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"fast"}`+"\n", w.String())
+	assert.Empty(t, errOut.String())
+}
+
+func TestLogger_WithRequestTimeout_outputs(t *testing.T) {
+	all := &syncBuffer{}
+	warn := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		Level:     zap.DebugLevel,
+		StripTime: true,
+		Output:    all,
+		Outputs:   []zapctxd.OutputSpec{{Writer: warn, MinLevel: zap.WarnLevel}},
+	}).WithRequestTimeout(time.Second)
+
+	c.Debug(context.Background(), "debug")
+	c.Warn(context.Background(), "warning")
+
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"warning"}`+"\n", warn.String())
+	assert.Contains(t, all.String(), `"msg":"debug"`)
+}
@@ -0,0 +1,76 @@
+package zapctxd
+
+import (
+	"crypto/rand"
+	"net"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// udpMaxDatagram is a maximum datagram size that fits common MTU without fragmentation.
+	udpMaxDatagram = 1400
+	// gelfChunkHeader is a size of GELF chunk header: magic bytes, message ID, sequence number and count.
+	gelfChunkHeader = 12
+	// gelfMaxChunks is a maximum number of chunks of a GELF message.
+	gelfMaxChunks = 128
+)
+
+// WithUDPOutput returns a clone of logger that ships every entry as a UDP datagram.
+//
+// Entries larger than MTU are split into GELF chunks, each chunk starts with magic bytes 0x1e 0x0f,
+// 8-byte message ID, sequence number and count of chunks. Entries that need more than 128 chunks
+// are dropped and counted in LoggerMetrics.DroppedEntries.
+// Original logger is not affected, Close of the clone closes the connection.
+func (l *Logger) WithUDPOutput(addr string) (*Logger, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.withOutput(zapcore.AddSync(udpWriter{conn: conn, metrics: l.metrics}), conn.Close)
+}
+
+type udpWriter struct {
+	conn    net.Conn
+	metrics *metrics
+}
+
+func (w udpWriter) Write(p []byte) (int, error) {
+	if len(p) <= udpMaxDatagram {
+		return w.conn.Write(p)
+	}
+
+	size := udpMaxDatagram - gelfChunkHeader
+	total := (len(p) + size - 1) / size
+
+	if total > gelfMaxChunks {
+		w.metrics.drop()
+
+		return len(p), nil
+	}
+
+	chunk := make([]byte, gelfChunkHeader, udpMaxDatagram)
+	chunk[0], chunk[1] = 0x1e, 0x0f
+
+	if _, err := rand.Read(chunk[2:10]); err != nil {
+		return 0, err
+	}
+
+	chunk[11] = byte(total)
+
+	for i := 0; i < total; i++ {
+		end := (i + 1) * size
+		if end > len(p) {
+			end = len(p)
+		}
+
+		chunk[10] = byte(i)
+
+		if _, err := w.conn.Write(append(chunk[:gelfChunkHeader], p[i*size:end]...)); err != nil {
+			return i * size, err
+		}
+	}
+
+	return len(p), nil
+}
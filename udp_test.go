@@ -0,0 +1,86 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithUDPOutput(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, pc.Close())
+	}()
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    bytes.NewBuffer(nil),
+	})
+
+	uc, err := c.WithUDPOutput(pc.LocalAddr().String())
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, uc.Close())
+	}()
+
+	read := func() []byte {
+		t.Helper()
+
+		buf := make([]byte, 65536)
+
+		require.NoError(t, pc.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := pc.ReadFrom(buf)
+		require.NoError(t, err)
+
+		return buf[:n]
+	}
+
+	uc.Info(context.Background(), "hello", "foo", 1)
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","foo":1}`+"\n", string(read()))
+
+	long := strings.Repeat("a", 3000)
+	uc.Info(context.Background(), "long", "value", long)
+
+	var (
+		payload []byte
+		id      []byte
+	)
+
+	for i, total := 0, 1; i < total; i++ {
+		d := read()
+		assert.LessOrEqual(t, len(d), 1400)
+		require.Greater(t, len(d), 12)
+
+		assert.Equal(t, []byte{0x1e, 0x0f}, d[:2])
+		assert.Equal(t, byte(i), d[10])
+
+		if id == nil {
+			id = d[2:10]
+		}
+
+		assert.Equal(t, id, d[2:10])
+
+		total = int(d[11])
+		payload = append(payload, d[12:]...)
+	}
+
+	assert.Greater(t, len(payload), 1400)
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"long","value":"`+long+`"}`+"\n", string(payload))
+
+	// Entries of more than 128 chunks are dropped.
+	uc.Info(context.Background(), "too long", "value", strings.Repeat("a", 128*1400))
+	uc.Info(context.Background(), "hello")
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello"}`+"\n", string(read()))
+	assert.Equal(t, int64(1), uc.Metrics().DroppedEntries)
+}
@@ -0,0 +1,16 @@
+package zapctxd
+
+import "time"
+
+// WithUTCFields returns a logger that converts time.Time values of fields to UTC.
+func (l *Logger) WithUTCFields() *Logger {
+	return l.withProcessor(func(e entry) []any {
+		for i := 1; i < len(e.kv); i += 2 {
+			if t, ok := e.kv[i].(time.Time); ok {
+				e.kv[i] = t.UTC()
+			}
+		}
+
+		return e.kv
+	})
+}
@@ -0,0 +1,28 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithUTCFields(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		Output: w,
+	}).WithUTCFields()
+
+	ts := time.Date(2024, 1, 2, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+	ctx := ctxd.AddFields(context.Background(), "created_at", ts)
+
+	c.Info(ctx, "hello", "updated_at", ts.Add(time.Hour))
+
+	assert.Contains(t, w.String(), `"updated_at":"2024-01-02T15:04:05.000Z","created_at":"2024-01-02T14:04:05.000Z"}`)
+}
@@ -0,0 +1,57 @@
+package zapctxd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bool64/ctxd"
+	"go.uber.org/multierr"
+)
+
+// Validate checks log entry without writing it.
+//
+// It reports nil context, odd number of key-value elements, non-string or empty keys,
+// values that fail JSON encoding and missing fields required with RequireAll.
+func (l *Logger) Validate(ctx context.Context, msg string, keysAndValues ...any) error {
+	var err error
+
+	if ctx == nil {
+		err = multierr.Append(err, errors.New("nil context"))
+	}
+
+	if len(keysAndValues)%2 != 0 {
+		err = multierr.Append(err, fmt.Errorf("odd number of key-value elements: %d", len(keysAndValues)))
+	}
+
+	kv := keysAndValues
+	if ctx != nil {
+		kv = append(kv[:len(kv):len(kv)], ctxd.Fields(ctx)...)
+	}
+
+	for i := 0; i < len(kv); i += 2 {
+		k, ok := kv[i].(string)
+
+		switch {
+		case !ok:
+			err = multierr.Append(err, fmt.Errorf("key at position %d is not a string: %T", i, kv[i]))
+		case k == "":
+			err = multierr.Append(err, fmt.Errorf("key at position %d is empty", i))
+		}
+
+		if i+1 >= len(kv) {
+			break
+		}
+
+		if _, jerr := json.Marshal(kv[i+1]); jerr != nil {
+			err = multierr.Append(err, fmt.Errorf("value of %v is not serializable: %w", kv[i], jerr))
+		}
+	}
+
+	if missing := missingKeys(kv, l.required); len(missing) > 0 {
+		err = multierr.Append(err, fmt.Errorf("missing required fields %v in %q", missing, msg))
+	}
+
+	return err
+}
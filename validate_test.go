@@ -0,0 +1,26 @@
+package zapctxd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_Validate(t *testing.T) {
+	c := zapctxd.New(zapctxd.Config{}).RequireAll("user_id", "tenant")
+
+	ctx := ctxd.AddFields(context.Background(), "tenant", "acme")
+
+	assert.NoError(t, c.Validate(ctx, "hello", "user_id", 1))
+
+	//nolint:staticcheck // Nil context is validated.
+	err := c.Validate(nil, "hello", "", 1, 2, func() {}, "odd")
+	assert.EqualError(t, err, "nil context; odd number of key-value elements: 5; "+
+		"key at position 0 is empty; key at position 2 is not a string: int; "+
+		"value of 2 is not serializable: json: unsupported type: func(); "+
+		"missing required fields [user_id tenant] in \"hello\"")
+}
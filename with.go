@@ -0,0 +1,108 @@
+package zapctxd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// With returns a child logger with permanently attached fields.
+//
+// Fields are written before call-site fields, child logger shares level enabler with parent.
+// Values can be key-value pairs or zap.Field.
+func (l *Logger) With(keysAndValues ...any) *Logger {
+	fields := make([]zap.Field, 0, len(keysAndValues)/2)
+
+	for i := 0; i < len(keysAndValues); i++ {
+		if f, ok := keysAndValues[i].(zap.Field); ok {
+			fields = append(fields, f)
+
+			continue
+		}
+
+		if i == len(keysAndValues)-1 {
+			fields = append(fields, zap.Any("!BADKEY", keysAndValues[i]))
+
+			break
+		}
+
+		k, ok := keysAndValues[i].(string)
+		if !ok {
+			k = fmt.Sprint(keysAndValues[i])
+		}
+
+		v := keysAndValues[i+1]
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+
+		fields = append(fields, zap.Any(k, v))
+		i++
+	}
+
+	return l.with(fields...)
+}
+
+// with returns a copy of logger with permanently attached fields.
+func (l *Logger) with(fields ...zap.Field) *Logger {
+	nl := *l
+
+	fields = l.sanitize(fields)
+
+	nl.fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	nl.zl = l.zl.With(fields...)
+	nl.zdebug = l.zdebug.With(fields...)
+	nl.sugared = nl.zl.Sugar()
+	nl.debug = nl.zdebug.Sugar()
+
+	return &nl
+}
+
+// WithContextFields returns a logger that adds key-value pairs returned by fn for context of every entry.
+func (l *Logger) WithContextFields(fn func(ctx context.Context) []any) *Logger {
+	return l.withProcessor(func(e entry) []any {
+		return append(e.kv, fn(e.ctx)...)
+	})
+}
+
+// WithNodeID returns a logger that adds node identifier to every entry.
+//
+// If nodeID is empty, it is taken from NODE_ID environment variable.
+// Field name can be configured with Config.FieldNames.NodeID, default "node_id".
+func (l *Logger) WithNodeID(nodeID string) *Logger {
+	if nodeID == "" {
+		nodeID = os.Getenv("NODE_ID")
+	}
+
+	return l.with(zap.String(orDefault(l.fieldNames.NodeID, "node_id"), nodeID))
+}
+
+// WithAnnotation returns a logger that adds platform annotation to every entry.
+//
+// Annotation key is prefixed with Config.AnnotationPrefix, default "@".
+func (l *Logger) WithAnnotation(key, value string) *Logger {
+	return l.with(zap.String(orDefault(l.cfg.AnnotationPrefix, "@")+key, value))
+}
+
+// Named returns a child logger with a name added to logger name, segments are separated with dots.
+//
+// Name is written in "logger" field, it can be configured with Config.FieldNames.Logger.
+func (l *Logger) Named(name string) *Logger {
+	nl := *l
+
+	switch {
+	case l.name == "":
+		nl.name = name
+	case name != "":
+		nl.name = l.name + "." + name
+	}
+
+	nl.zl = l.zl.Named(name)
+	nl.zdebug = l.zdebug.Named(name)
+	nl.sugared = nl.zl.Sugar()
+	nl.debug = nl.zdebug.Sugar()
+
+	return &nl
+}
@@ -0,0 +1,163 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_WithNodeID(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	c.WithNodeID("node-1").Info(context.Background(), "hello", "foo", 1)
+
+	t.Setenv("NODE_ID", "node-2")
+	c.WithNodeID("").Info(context.Background(), "hello")
+
+	c.Info(context.Background(), "parent is not affected")
+
+	buf := bytes.NewBuffer(nil)
+	c.WithNodeID("node-3").Info(ctxd.WithLogWriter(context.Background(), buf), "redirected")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","node_id":"node-1","foo":1}
+{"level":"info","time":"<stripped>","msg":"hello","node_id":"node-2"}
+{"level":"info","time":"<stripped>","msg":"parent is not affected"}
+`, w.String())
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"redirected","node_id":"node-3"}
+`, buf.String())
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime:  true,
+		Output:     w,
+		FieldNames: zapctxd.FieldNames{NodeID: "host.id"},
+	})
+
+	c.WithNodeID("node-1").Info(context.Background(), "hello")
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","host.id":"node-1"}
+`, w.String())
+}
+
+func TestLogger_WithContextFields(t *testing.T) {
+	type ctxKey struct{}
+
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	}).WithContextFields(func(ctx context.Context) []any {
+		if id, ok := ctx.Value(ctxKey{}).(string); ok {
+			return []any{"tenant", id}
+		}
+
+		return nil
+	})
+
+	c.Info(context.WithValue(context.Background(), ctxKey{}, "acme"), "hello", "foo", 1)
+	c.Info(context.Background(), "no tenant")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","foo":1,"tenant":"acme"}
+{"level":"info","time":"<stripped>","msg":"no tenant"}
+`, w.String())
+}
+
+func TestLogger_WithAnnotation(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	c.WithAnnotation("alert", "pager").Info(context.Background(), "hello")
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime:        true,
+		Output:           w,
+		AnnotationPrefix: "annotation.",
+	})
+
+	c.WithAnnotation("alert", "pager").Info(context.Background(), "hello")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"hello","@alert":"pager"}
+{"level":"info","time":"<stripped>","msg":"hello","annotation.alert":"pager"}
+`, w.String())
+}
+
+func TestLogger_With(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	parent := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    buf,
+	})
+
+	child := parent.With("component", "db", zap.Int("shard", 2), "error", errors.New("degraded"))
+	grandchild := child.With("table", "users").SkipCaller()
+
+	ctx := ctxd.AddFields(context.Background(), "request_id", "r1")
+
+	parent.Info(ctx, "parent")
+	child.Info(ctx, "child", "foo", "bar")
+	grandchild.Info(ctx, "grandchild")
+
+	parent.SetLevelEnabler(zap.WarnLevel)
+	child.Info(ctx, "child skipped")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"parent","request_id":"r1"}
+{"level":"info","time":"<stripped>","msg":"child","component":"db","shard":2,"error":"degraded","foo":"bar","request_id":"r1"}
+{"level":"info","time":"<stripped>","msg":"grandchild","component":"db","shard":2,"error":"degraded","table":"users","request_id":"r1"}
+`, buf.String())
+}
+
+func TestLogger_Named(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	n := c.Named("db")
+	n.Info(context.Background(), "hello")
+	n.Named("pool").With("foo", 1).Info(context.Background(), "nested")
+	n.Named("").Info(context.Background(), "same name")
+	c.Info(context.Background(), "parent is not affected")
+
+	buf := bytes.NewBuffer(nil)
+	n.Info(ctxd.WithLogWriter(context.Background(), buf), "redirected")
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","logger":"db","msg":"hello"}
+{"level":"info","time":"<stripped>","logger":"db.pool","msg":"nested","foo":1}
+{"level":"info","time":"<stripped>","logger":"db","msg":"same name"}
+{"level":"info","time":"<stripped>","msg":"parent is not affected"}
+`, w.String())
+	assert.Equal(t, `{"level":"info","time":"<stripped>","logger":"db","msg":"redirected"}
+`, buf.String())
+
+	w.Reset()
+
+	c = zapctxd.New(zapctxd.Config{
+		StripTime:  true,
+		Output:     w,
+		FieldNames: zapctxd.FieldNames{Logger: "component"},
+	})
+
+	c.Named("db").WithMaxDepth(3).Info(context.Background(), "hello")
+	assert.Equal(t, `{"level":"info","time":"<stripped>","component":"db","msg":"hello"}
+`, w.String())
+}
@@ -0,0 +1,170 @@
+package zapctxd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ io.Writer = &Logger{}
+
+// Write implements io.Writer by logging every line of p as a message at Info level.
+//
+// Lines with JSON objects are logged with their fields, "msg" or "message" field is used as a message.
+// It allows using logger as output of other loggers, e.g. log.New(logger, "", 0) for http.Server.ErrorLog.
+func (l *Logger) Write(p []byte) (int, error) {
+	ctx := context.Background()
+
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if line[0] == '{' {
+			if msg, kv, ok := parseJSONLine(line); ok {
+				l.Info(ctx, msg, kv...)
+
+				continue
+			}
+		}
+
+		l.Info(ctx, string(line))
+	}
+
+	return len(p), nil
+}
+
+// StdLogger returns standard library logger that writes every line as a message at level with fields of ctx.
+//
+// Levels above error are written as error, so that lines do not panic or exit.
+func (l *Logger) StdLogger(level zapcore.Level, ctx context.Context) *log.Logger { //nolint:revive // Level is the primary argument.
+	// Frames of log.Logger method, its output and stdWriter.Write are skipped to report caller of log.Logger.
+	l = l.SkipCaller().SkipCaller().SkipCaller()
+
+	return log.New(stdWriter{l: l, level: level, ctx: ctx}, "", 0)
+}
+
+// StdLoggerAt returns standard library logger that writes every line as a message at level.
+//
+// Levels above error are written as error, so that lines do not panic or exit.
+func (l *Logger) StdLoggerAt(level zapcore.Level) *log.Logger {
+	return l.StdLogger(level, context.Background())
+}
+
+// stdWriter logs lines of written data at level, it is stateless and safe for concurrent use.
+//
+// Levels above error are mapped to error.
+type stdWriter struct {
+	l     *Logger
+	level zapcore.Level
+	ctx   context.Context //nolint:containedctx // Context provides fields of all lines.
+}
+
+func (w stdWriter) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		switch w.level {
+		case zap.DebugLevel:
+			w.l.Debug(w.ctx, string(line))
+		case zap.InfoLevel:
+			w.l.Info(w.ctx, string(line))
+		case zap.WarnLevel:
+			w.l.Warn(w.ctx, string(line))
+		default:
+			w.l.Error(w.ctx, string(line))
+		}
+	}
+
+	return len(p), nil
+}
+
+// Writer returns io.Writer that logs every line of written data as a message at level.
+//
+// Incomplete line is buffered until it is completed by subsequent writes, writer is safe for concurrent use.
+// Levels above error are written as error, so that lines do not panic or exit.
+func (l *Logger) Writer(level zapcore.Level) io.Writer {
+	// Frames of lineWriter.Write and stdWriter.Write are skipped to report caller of Write.
+	l = l.SkipCaller().SkipCaller()
+
+	return &lineWriter{stdWriter: stdWriter{l: l, level: level, ctx: context.Background()}}
+}
+
+// lineWriter buffers data until newline.
+type lineWriter struct {
+	stdWriter
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+
+	_, _ = w.stdWriter.Write(w.buf[:i]) //nolint:errcheck // stdWriter does not fail.
+
+	w.buf = append(w.buf[:0], w.buf[i+1:]...)
+
+	return len(p), nil
+}
+
+// parseJSONLine returns message and fields of a JSON object in original order.
+func parseJSONLine(line []byte) (string, []any, bool) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+
+	if _, err := dec.Token(); err != nil {
+		return "", nil, false
+	}
+
+	var (
+		msg string
+		kv  []any
+	)
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return "", nil, false
+		}
+
+		k, _ := t.(string) //nolint:errcheck // JSON object keys are strings.
+
+		var v any
+
+		if err := dec.Decode(&v); err != nil {
+			return "", nil, false
+		}
+
+		if s, ok := v.(string); ok && msg == "" && (k == "msg" || k == "message") {
+			msg = s
+
+			continue
+		}
+
+		kv = append(kv, k, v)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return "", nil, false
+	}
+
+	return msg, kv, true
+}
@@ -0,0 +1,137 @@
+package zapctxd_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bool64/zapctxd"
+)
+
+func TestLogger_Write(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	log.New(c, "", 0).Print("http: TLS handshake error")
+
+	p := []byte("first\n\nsecond\n" + `{"message":"structured","status":500,"ok":false}` + "\n{broken\n")
+	n, err := c.Write(p)
+	assert.NoError(t, err)
+	assert.Equal(t, len(p), n)
+
+	assert.Equal(t, `{"level":"info","time":"<stripped>","msg":"http: TLS handshake error"}
+{"level":"info","time":"<stripped>","msg":"first"}
+{"level":"info","time":"<stripped>","msg":"second"}
+{"level":"info","time":"<stripped>","msg":"structured","status":500,"ok":false}
+{"level":"info","time":"<stripped>","msg":"{broken"}
+`, w.String())
+}
+
+func TestLogger_StdLogger(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	sl := c.StdLogger(zap.WarnLevel, ctxd.AddFields(context.Background(), "component", "legacy"))
+	sl.Print("deprecated call")
+	sl.Printf("retry %d\nsecond line", 2)
+
+	c.StdLoggerAt(zap.ErrorLevel).Println("failed")
+	c.StdLoggerAt(zap.DebugLevel).Println("skipped")
+
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"deprecated call","component":"legacy"}
+{"level":"warn","time":"<stripped>","msg":"retry 2","component":"legacy"}
+{"level":"warn","time":"<stripped>","msg":"second line","component":"legacy"}
+{"level":"error","time":"<stripped>","msg":"failed"}
+`, w.String())
+}
+
+func TestLogger_Writer(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	lw := c.Writer(zap.WarnLevel)
+
+	_, err := lw.Write([]byte("partial "))
+	require.NoError(t, err)
+	assert.Empty(t, w.String())
+
+	_, err = lw.Write([]byte("line\nnext"))
+	require.NoError(t, err)
+
+	_, err = lw.Write([]byte(" line\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"level":"warn","time":"<stripped>","msg":"partial line"}
+{"level":"warn","time":"<stripped>","msg":"next line"}
+`, w.String())
+}
+
+func TestLogger_Writer_httpServer(t *testing.T) {
+	w := &syncBuffer{}
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		Output:    w,
+	})
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	srv.Config.ErrorLog = log.New(c.Writer(zap.ErrorLevel), "", 0)
+	srv.Start()
+
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Contains(t, w.String(), `{"level":"error","time":"<stripped>","msg":"http: superfluous response.WriteHeader call from`)
+}
+
+func TestLogger_Writer_caller(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+
+	c := zapctxd.New(zapctxd.Config{
+		StripTime: true,
+		DevMode:   true,
+		Output:    w,
+	})
+
+	_, _, line, _ := runtime.Caller(0)
+
+	c.StdLoggerAt(zap.WarnLevel).Print("std")
+	c.StdLogger(zap.WarnLevel, context.Background()).Printf("std %d", 2)
+	_, err := c.Writer(zap.WarnLevel).Write([]byte("writer\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, fmt.Sprintf("<stripped>\tWARN\tzapctxd/writer_test.go:%d\tstd\n"+
+		"<stripped>\tWARN\tzapctxd/writer_test.go:%d\tstd 2\n"+
+		"<stripped>\tWARN\tzapctxd/writer_test.go:%d\twriter\n", line+2, line+3, line+4), w.String())
+}
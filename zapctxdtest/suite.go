@@ -0,0 +1,90 @@
+package zapctxdtest
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/bool64/zapctxd"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// SuiteLogger is a debug level logger that captures entries of each test of testify suite separately.
+type SuiteLogger struct {
+	*zapctxd.Logger
+
+	out *suiteOutput
+}
+
+// NewSuiteLogger creates a logger for testify suite.
+//
+// Captured entries of current test are available with SuiteLogger.Captured, they are reset when test is
+// finished and reported with test log if test has failed.
+func NewSuiteLogger(s *suite.Suite) *SuiteLogger {
+	out := &suiteOutput{s: s}
+
+	return &SuiteLogger{
+		Logger: zapctxd.New(zapctxd.Config{
+			Level:  zap.DebugLevel,
+			Output: out,
+		}),
+		out: out,
+	}
+}
+
+// Captured returns entries written in current test.
+func (l *SuiteLogger) Captured() string {
+	return l.out.String()
+}
+
+// suiteOutput collects written entries of current test of suite.
+type suiteOutput struct {
+	s *suite.Suite
+
+	mu  sync.Mutex
+	t   *testing.T
+	buf bytes.Buffer
+}
+
+func (o *suiteOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if t := o.s.T(); t != o.t {
+		o.t = t
+		o.buf.Reset()
+
+		t.Cleanup(func() { o.tearDown(t) })
+	}
+
+	return o.buf.Write(p)
+}
+
+// tearDown reports entries of failed test and resets output on test completion.
+func (o *suiteOutput) tearDown(t *testing.T) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.t != t {
+		return
+	}
+
+	if t.Failed() && o.buf.Len() > 0 {
+		t.Log("log entries:\n" + o.buf.String())
+	}
+
+	o.t = nil
+	o.buf.Reset()
+}
+
+func (o *suiteOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.t != o.s.T() {
+		return ""
+	}
+
+	return o.buf.String()
+}
@@ -0,0 +1,44 @@
+package zapctxdtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/bool64/zapctxd/zapctxdtest"
+)
+
+type loggerSuite struct {
+	suite.Suite
+
+	logger *zapctxdtest.SuiteLogger
+}
+
+func (s *loggerSuite) SetupSuite() {
+	s.logger = zapctxdtest.NewSuiteLogger(&s.Suite)
+}
+
+func (s *loggerSuite) TearDownTest() {
+	s.Contains(s.logger.Captured(), `"msg":"`+s.T().Name()[len("TestSuiteLogger/"):]+`"`)
+}
+
+func (s *loggerSuite) TestFirst() {
+	s.Empty(s.logger.Captured())
+
+	s.logger.Debug(context.Background(), "TestFirst", "foo", "bar")
+
+	s.Contains(s.logger.Captured(), `"msg":"TestFirst","foo":"bar"`)
+}
+
+func (s *loggerSuite) TestSecond() {
+	s.Empty(s.logger.Captured())
+
+	s.logger.Info(context.Background(), "TestSecond")
+
+	s.NotContains(s.logger.Captured(), "TestFirst")
+}
+
+func TestSuiteLogger(t *testing.T) {
+	suite.Run(t, new(loggerSuite))
+}
@@ -0,0 +1,68 @@
+// Package zapctxdtest provides helpers to use contextualized logger in tests.
+package zapctxdtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bool64/zapctxd"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Option configures test mode.
+type Option func(m *testMode)
+
+// IgnoreErrors allows errors with messages containing any of patterns.
+func IgnoreErrors(patterns ...string) Option {
+	return func(m *testMode) {
+		m.ignore = append(m.ignore, patterns...)
+	}
+}
+
+type testMode struct {
+	tb     testing.TB
+	ignore []string
+}
+
+func (m *testMode) Fire(level zapcore.Level, msg string, fields []zapcore.Field) error {
+	if level < zap.ErrorLevel {
+		return nil
+	}
+
+	for _, p := range m.ignore {
+		if strings.Contains(msg, p) {
+			return nil
+		}
+	}
+
+	m.tb.Errorf("unexpected error log entry %q %v", msg, fieldsKV(fields))
+
+	return nil
+}
+
+// TestMode returns a copy of logger that fails the test on every written entry of Error level or above.
+//
+// Expected errors can be allowed with IgnoreErrors option.
+func TestMode(t testing.TB, l *zapctxd.Logger, options ...Option) *zapctxd.Logger {
+	m := &testMode{tb: t}
+
+	for _, o := range options {
+		o(m)
+	}
+
+	return l.WithHook(m)
+}
+
+// fieldsKV converts fields to key-value pairs.
+func fieldsKV(fields []zapcore.Field) []any {
+	enc := zapcore.NewMapObjectEncoder()
+	kv := make([]any, 0, 2*len(fields))
+
+	for _, f := range fields {
+		f.AddTo(enc)
+		kv = append(kv, f.Key, enc.Fields[f.Key])
+	}
+
+	return kv
+}
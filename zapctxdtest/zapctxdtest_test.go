@@ -0,0 +1,38 @@
+package zapctxdtest_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bool64/zapctxd"
+	"github.com/bool64/zapctxd/zapctxdtest"
+)
+
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTestMode(t *testing.T) {
+	tb := &recordingTB{TB: t}
+
+	c := zapctxdtest.TestMode(tb, zapctxd.New(zapctxd.Config{
+		Output: bytes.NewBuffer(nil),
+	}), zapctxdtest.IgnoreErrors("expected"))
+
+	ctx := context.Background()
+
+	c.Warn(ctx, "just a warning")
+	c.Error(ctx, "expected failure")
+	c.Error(ctx, "broken", "foo", "bar")
+
+	assert.Equal(t, []string{`unexpected error log entry "broken" [foo bar]`}, tb.errors)
+}